@@ -0,0 +1,28 @@
+// Package gotaskviewer embeds the web assets so the server binary can run
+// from any working directory.
+package gotaskviewer
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed cmd/server/templates static data
+var assets embed.FS
+
+// Templates holds the HTML templates (index.html).
+var Templates = mustSub("cmd/server/templates")
+
+// Static holds the CSS and JavaScript served under /static/.
+var Static = mustSub("static")
+
+// Data holds the images served under /data/.
+var Data = mustSub("data")
+
+func mustSub(dir string) fs.FS {
+	sub, err := fs.Sub(assets, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
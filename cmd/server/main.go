@@ -2,52 +2,112 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"html/template"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
+	gotaskviewer "github.com/erickgnclvs/go-task-viewer"
+	"github.com/erickgnclvs/go-task-viewer/internal/config"
+	"github.com/erickgnclvs/go-task-viewer/internal/fx"
 	"github.com/erickgnclvs/go-task-viewer/internal/handlers"
+	"github.com/erickgnclvs/go-task-viewer/internal/parser"
+	"github.com/erickgnclvs/go-task-viewer/internal/store"
 )
 
 func main() {
 	log.Println("Starting Go Task Viewer application...")
 
-	// Path relative to the project root directory (where 'go run' is executed)
-	tmplPath := "cmd/server/templates/index.html"
-	tmpl, err := template.ParseFiles(tmplPath)
+	// Settings come from flags, then environment variables, then the optional config file
+	cfg, err := config.Load(os.Args[1:], os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		config.Usage(os.Stdout)
+		return
+	}
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	// Templates are embedded in the binary; TemplateDir points at a directory
+	// with a custom index.html to use instead
+	templateFS := gotaskviewer.Templates
+	templateSource := "embedded assets"
+	if cfg.TemplateDir != "" {
+		templateFS = os.DirFS(cfg.TemplateDir)
+		templateSource = cfg.TemplateDir
+	}
+	tmpl, err := template.ParseFS(templateFS, "index.html")
 	if err != nil {
-		log.Fatalf("Error loading template from %s: %v", tmplPath, err)
+		log.Fatalf("Error loading template from %s: %v", templateSource, err)
+	}
+	log.Printf("Template loaded successfully from %s.", templateSource)
+
+	// Open the task history file ("off" disables it)
+	var history *store.Store
+	if cfg.HistoryFile != "off" {
+		history, err = store.Open(cfg.HistoryFile)
+		if err != nil {
+			log.Fatalf("Error opening task history: %v", err)
+		}
+	}
+
+	// Directory for saved results behind /r/{id} permalinks ("off" disables them)
+	var results *store.Results
+	if cfg.ResultsDir != "off" {
+		results, err = store.OpenResults(cfg.ResultsDir)
+		if err != nil {
+			log.Fatalf("Error opening saved results: %v", err)
+		}
+	}
+
+	// Local exchange rate table for converting totals into the reporting currency (optional)
+	var rates *fx.Table
+	if cfg.FXRatesFile != "" {
+		rates, err = fx.Load(cfg.FXRatesFile)
+		if err != nil {
+			log.Fatalf("Error loading exchange rates: %v", err)
+		}
+		log.Printf("Loaded %d exchange rates from %s; totals are converted into %s", rates.Len(), cfg.FXRatesFile, cfg.ReportCurrency)
+	}
+
+	// CSV column mapping profiles offered in the upload form (optional)
+	var profiles parser.Profiles
+	if cfg.ProfilesDir != "" {
+		profiles, err = parser.LoadProfiles(cfg.ProfilesDir)
+		if err != nil {
+			log.Fatalf("Error loading mapping profiles: %v", err)
+		}
+		log.Printf("Loaded %d mapping profiles from %s", len(profiles), cfg.ProfilesDir)
 	}
-	log.Printf("Template loaded successfully from %s.", tmplPath)
 
 	// Setup HTTP server
 	mux := http.NewServeMux()
 
-	// Serve static files (CSS, JS) from the static directory relative to project root
-	staticFilesPath := "static"
-	fs := http.FileServer(http.Dir(staticFilesPath))
-	mux.Handle("/static/", http.StripPrefix("/static/", fs))
-	log.Printf("Serving static files from '%s' under '/static/'", staticFilesPath)
-
-	// Serve data files (like GIFs) from the data directory relative to project root
-	dataFilesPath := "data" // Assumes 'data' directory is at the project root
-	dataFs := http.FileServer(http.Dir(dataFilesPath))
-	mux.Handle("/data/", http.StripPrefix("/data/", dataFs))
-	log.Printf("Serving data files from '%s' under '/data/'", dataFilesPath) // Added log
+	// Serve static files (CSS, JS) and data files (like GIFs) from the embedded
+	// assets, or static files from StaticDir if one is configured
+	staticFS := gotaskviewer.Static
+	if cfg.StaticDir != "" {
+		staticFS = os.DirFS(cfg.StaticDir)
+		log.Printf("Serving static files from '%s' under '/static/'", cfg.StaticDir)
+	}
+	mux.Handle("/static/", http.StripPrefix("/static/", http.FileServerFS(staticFS)))
+	mux.Handle("/data/", http.StripPrefix("/data/", http.FileServerFS(gotaskviewer.Data)))
 
 	// Register handlers from the handlers package
-	mux.HandleFunc("/", handlers.HomeHandler(tmpl))
-	mux.HandleFunc("/analyze", handlers.AnalyzeHandler(tmpl))
+	mux.HandleFunc("/", handlers.HomeHandler(cfg, tmpl, history, profiles))
+	mux.HandleFunc("/analyze", handlers.AnalyzeHandler(cfg, tmpl, history, results, rates, profiles))
+	mux.HandleFunc("/history", handlers.HistoryHandler(cfg, tmpl, history, rates, profiles))
+	mux.HandleFunc("/export", handlers.ExportHandler(cfg, history, results, profiles))
+	mux.HandleFunc("/share", handlers.ShareHandler(cfg, results, profiles))
+	mux.HandleFunc("/r/", handlers.ResultHandler(cfg, tmpl, history, results, rates, profiles))
 	mux.HandleFunc("/health", handlers.HealthHandler)
+	mux.HandleFunc("/api/v1/analyze", handlers.APIAnalyzeHandler(cfg, profiles))
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
-	}
+	port := cfg.Port
 
 	server := &http.Server{
 		Addr:    ":" + port,
@@ -69,7 +129,7 @@ func main() {
 	log.Println("Shutting down server...")
 
 	// Create a deadline context for shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 
 	// Attempt graceful shutdown
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/analyzer"
+	"github.com/erickgnclvs/go-task-viewer/internal/export"
+	"github.com/erickgnclvs/go-task-viewer/internal/fx"
+	"github.com/erickgnclvs/go-task-viewer/internal/parser"
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// Output formats for the analyze command
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputCSV   = "csv"
+)
+
+// runAnalyze implements "task-viewer analyze" and returns the process exit code.
+func runAnalyze(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	output := flags.String("output", outputTable, "output format: table, json or csv")
+	inputFormat := flags.String("input", "", "input format (csv, tsv, semicolon, text, labeled, json); detected if empty")
+	profileFile := flags.String("profile", "", "JSON mapping profile for the CSV column and pay type names of another platform")
+	decimal := flags.String("decimal", "", "decimal separator of amounts such as 1.234 that could be read either way: . or ,; guessed if empty")
+	fromStr := flags.String("from", "", "only include tasks worked on or after this date (e.g. 2025-03-01)")
+	toStr := flags.String("to", "", "only include tasks worked on or before this date")
+	var projects []string
+	flags.Func("project", "only include this project; repeat or comma-separate for several", func(value string) error {
+		for _, project := range strings.Split(value, ",") {
+			if project = strings.TrimSpace(project); project != "" {
+				projects = append(projects, project)
+			}
+		}
+		return nil
+	})
+	ratesFile := flags.String("fx-rates", "", "CSV or JSON exchange rate table; adds the total converted into -report-currency (table output)")
+	reportCurrency := flags.String("report-currency", "BRL", "currency code totals are converted into")
+	defaultCurrency := flags.String("default-currency", "USD", "currency code of amounts written without one")
+	details := flags.Bool("details", false, "also list every task (table output)")
+	verbose := flags.Bool("v", false, "print debug logs to stderr")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	switch *output {
+	case outputTable, outputJSON, outputCSV:
+	default:
+		fmt.Fprintf(stderr, "task-viewer: unknown output format %q\n", *output)
+		return 2
+	}
+	if *inputFormat != "" && !parser.IsFormat(*inputFormat) {
+		fmt.Fprintf(stderr, "task-viewer: unknown input format %q\n", *inputFormat)
+		return 2
+	}
+	from, to, err := parseDateRange(*fromStr, *toStr)
+	if err != nil {
+		fmt.Fprintf(stderr, "task-viewer: %v\n", err)
+		return 2
+	}
+	style, err := parser.ParseNumberStyle(*decimal)
+	if err != nil {
+		fmt.Fprintf(stderr, "task-viewer: %v\n", err)
+		return 2
+	}
+	if flags.NArg() > 1 {
+		fmt.Fprintln(stderr, "task-viewer: analyze takes at most one file")
+		return 2
+	}
+
+	var profile *parser.Profile
+	if *profileFile != "" {
+		if profile, err = parser.LoadProfile(*profileFile); err != nil {
+			fmt.Fprintf(stderr, "task-viewer: %v\n", err)
+			return 2
+		}
+	}
+
+	var rates *fx.Table
+	if *ratesFile != "" {
+		if rates, err = fx.Load(*ratesFile); err != nil {
+			fmt.Fprintf(stderr, "task-viewer: %v\n", err)
+			return 2
+		}
+	}
+
+	// The parser and analyzer log every step; keep the terminal for the results
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	raw, err := readInput(flags.Arg(0), stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "task-viewer: %v\n", err)
+		return 1
+	}
+
+	detection := parser.Specified(*inputFormat)
+	if *inputFormat == "" {
+		detection = profile.DetectFormat(raw)
+	}
+	tasks, diagnostics := profile.Parse(detection.Format, raw, style)
+	for _, d := range diagnostics {
+		fmt.Fprintln(stderr, formatDiagnostic(d))
+	}
+	if len(tasks) == 0 {
+		fmt.Fprintln(stderr, "task-viewer: no tasks found in input")
+		return 1
+	}
+
+	tasks = parser.FillMissingCategories(tasks)
+	tasks = analyzer.FilterByProject(analyzer.FilterByDate(tasks, from, to), projects)
+	tasks = analyzer.SortByDate(tasks)
+	summary := analyzer.AnalyzeData(tasks)
+
+	switch *output {
+	case outputJSON:
+		err = export.WriteJSON(stdout, summary, tasks)
+	case outputCSV:
+		err = export.WriteSummaryCSV(stdout, summary)
+	default:
+		var conversion *types.Conversion
+		if rates != nil {
+			c := rates.Convert(tasks, strings.ToUpper(*reportCurrency), strings.ToUpper(*defaultCurrency))
+			conversion = &c
+		}
+		if !*details {
+			tasks = nil
+		}
+		err = writeTable(stdout, detection, summary, conversion, tasks)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "task-viewer: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// readInput reads the whole of path, or stdin if path is empty or "-", and
+// decodes it to UTF-8 text.
+func readInput(path string, stdin io.Reader) (string, error) {
+	var data []byte
+	var err error
+	if path == "" || path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	input, encoding := parser.DecodeInput(data)
+	log.Printf("[DEBUG] Input is %s", encoding)
+	return input, nil
+}
+
+// parseDateRange parses the -from and -to flags; either may be empty.
+func parseDateRange(fromStr, toStr string) (from, to time.Time, err error) {
+	if fromStr != "" {
+		if from, err = parser.ParseDate(fromStr); err != nil {
+			return from, to, fmt.Errorf("invalid -from date: %v", err)
+		}
+	}
+	if toStr != "" {
+		if to, err = parser.ParseDate(toStr); err != nil {
+			return from, to, fmt.Errorf("invalid -to date: %v", err)
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return from, to, fmt.Errorf("-to date is before -from date")
+	}
+	return from, to, nil
+}
+
+// formatDiagnostic renders a parse diagnostic as a single stderr line.
+func formatDiagnostic(d types.Diagnostic) string {
+	location := "input"
+	if d.Line > 0 {
+		location = fmt.Sprintf("line %d", d.Line)
+	}
+	if d.Field != "" {
+		location += " (" + d.Field + ")"
+	}
+	return fmt.Sprintf("%s: %s: %s", d.Severity, location, d.Message)
+}
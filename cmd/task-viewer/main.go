@@ -0,0 +1,37 @@
+// Command task-viewer analyzes task exports from the terminal, without the web server.
+//
+//	task-viewer analyze [flags] [file]
+//
+// With no file, or "-", the input is read from stdin.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `Usage:
+  task-viewer analyze [flags] [file]
+
+Reads a CSV, TSV, text or JSON task export from file, or stdin if file is
+omitted or "-", and prints the summary.
+
+Run "task-viewer analyze -h" for the flags.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "analyze":
+		os.Exit(runAnalyze(os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+	case "help", "-h", "-help", "--help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "task-viewer: unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/export"
+	"github.com/erickgnclvs/go-task-viewer/internal/i18n"
+	"github.com/erickgnclvs/go-task-viewer/internal/parser"
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// writeTable prints the summary as aligned plain-text tables. Tasks are
+// listed at the end if any are given. Input in more than one currency gets a
+// warning and a per-currency table first. conversion, if not nil, adds the
+// total converted into the reporting currency.
+func writeTable(w io.Writer, detection parser.Detection, summary types.Summary, conversion *types.Conversion, tasks []types.Task) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	symbol := "$"
+	if code := export.SummaryCurrency(summary); code != "" {
+		symbol = parser.CurrencySymbol(code)
+	}
+
+	if summary.MixedCurrencies {
+		fmt.Fprintln(tw, "WARNING: tasks are in more than one currency; totals below add them together unconverted.")
+		fmt.Fprintln(tw, "CURRENCY\tITEMS\tHOURS\tVALUE")
+		for _, c := range summary.Currencies {
+			code := c.Currency
+			if code == "" {
+				code = "(not stated)"
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%.2f\t%s\n", code, c.ItemCount, c.Time.Hours(), c.Value)
+		}
+		fmt.Fprintln(tw)
+	}
+
+	fmt.Fprintf(tw, "Input format:\t%s (%.0f%% confidence)\n", detection.Format, detection.Confidence*100)
+	fmt.Fprintf(tw, "Tasks:\t%d\n", summary.TotalTasks)
+	fmt.Fprintf(tw, "Hours:\t%.2f\n", summary.TotalTime.Hours())
+	fmt.Fprintf(tw, "Total value:\t%s%s\n", symbol, summary.TotalValue)
+	if conversion != nil {
+		fmt.Fprintf(tw, "Total value in %s:\t%s%s\n", conversion.Currency, parser.CurrencySymbol(conversion.Currency), conversion.Value)
+		if conversion.Unconverted > 0 {
+			fmt.Fprintf(tw, "\t(%d tasks left out, no exchange rate for: %s)\n", conversion.Unconverted, i18n.English.MissingRates(conversion.MissingRates))
+		}
+	}
+	fmt.Fprintf(tw, "Average hourly rate:\t%s%s/hr\n", symbol, summary.AverageHourlyRate)
+	fmt.Fprintf(tw, "Average time per task:\t%.2f min\n", summary.AvgTimePerTask.Minutes())
+	fmt.Fprintf(tw, "Average value per task:\t%s%s\n", symbol, summary.AvgValuePerTask)
+
+	fmt.Fprintln(tw, "\nTYPE\tITEMS\tHOURS\tVALUE")
+	writeBucketLine(tw, "Task", summary.Tasks, symbol)
+	writeBucketLine(tw, "Exceeded Time", summary.ExceededTime, symbol)
+	writeBucketLine(tw, "Other", summary.Other, symbol)
+
+	fmt.Fprintln(tw, "\nSTATUS\tITEMS\tHOURS\tVALUE")
+	writeBucketLine(tw, "Paid", summary.Status.Paid, symbol)
+	writeBucketLine(tw, "Approved", summary.Status.Approved, symbol)
+	writeBucketLine(tw, "Pending", summary.Status.Pending, symbol)
+	writeBucketLine(tw, "Rejected", summary.Status.Rejected, symbol)
+	fmt.Fprintf(tw, "Confirmed\t\t\t%s%s\n", symbol, summary.Status.Confirmed)
+	fmt.Fprintf(tw, "Expected\t\t\t%s%s\n", symbol, summary.Status.Expected)
+
+	if len(summary.Projects) > 0 {
+		fmt.Fprintln(tw, "\nPROJECT\tTASKS\tHOURS\tVALUE\tRATE\tAVG TIME")
+		for _, p := range summary.Projects {
+			name := p.Name
+			if name == "" {
+				name = "(no project)"
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%.2f\t%s%s\t%s%s/hr\t%.2f min\n", name, p.TaskCount, p.TotalTime.Hours(), symbol, p.Value, symbol, p.EffectiveRate, p.AvgTimePerTask.Minutes())
+		}
+	}
+
+	if len(summary.Monthly) > 0 {
+		fmt.Fprintln(tw, "\nMONTH\tITEMS\tHOURS\tVALUE\tRATE")
+		for _, p := range summary.Monthly {
+			fmt.Fprintf(tw, "%s\t%d\t%.2f\t%s%s\t%s%s/hr\n", p.Period, p.ItemCount, p.Time.Hours(), symbol, p.Value, symbol, p.EffectiveRate)
+		}
+	}
+	if summary.UndatedItems > 0 {
+		fmt.Fprintf(tw, "\n%d items without a readable date are left out of the monthly totals.\n", summary.UndatedItems)
+	}
+
+	if len(tasks) > 0 {
+		fmt.Fprintln(tw, "\nDATE\tID\tPROJECT\tDURATION\tRATE\tVALUE\tTYPE\tSTATUS")
+		for _, t := range tasks {
+			taskSymbol := symbol
+			if t.Currency != "" {
+				taskSymbol = parser.CurrencySymbol(t.Currency)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s%s/hr\t%s%s\t%s\t%s\n", t.Date, t.ID, t.Category, t.Duration, taskSymbol, t.Rate, taskSymbol, t.Value, t.Type, t.Status)
+		}
+	}
+
+	return tw.Flush()
+}
+
+// writeBucketLine prints one row of the type or status table.
+func writeBucketLine(w io.Writer, label string, bucket types.Bucket, symbol string) {
+	fmt.Fprintf(w, "%s\t%d\t%.2f\t%s%s\n", label, bucket.Count, bucket.Time.Hours(), symbol, bucket.Value)
+}
@@ -2,91 +2,191 @@ package analyzer
 
 import (
 	"log"
+	"sort"
+	"strings"
 
 	"github.com/erickgnclvs/go-task-viewer/internal/types"
 )
 
 // AnalyzeData processes a slice of tasks and calculates summary statistics.
-func AnalyzeData(tasks []types.Task) map[string]interface{} {
-	totalTasks := 0               // Count of items explicitly marked as "Task"
-	totalTasksValue := 0.0        // Sum of value for "Task" items
-	totalExceededTimeValue := 0.0 // Sum of value for "Exceeded Time" items
-	totalOtherValue := 0.0        // Sum of value for all other item types (Mission Reward, Operation, etc.)
-	totalHours := 0.0             // Sum of duration (in hours) for all item types
-
-	// Detailed hour breakdowns
-	taskHours := 0.0         // Sum of duration (in hours) for "Task" items
-	exceededTimeHours := 0.0 // Sum of duration (in hours) for "Exceeded Time" items
-	otherHours := 0.0        // Sum of duration (in hours) for all other item types
-
-	// For average calculations specific to "Task" items
-	totalTaskTime := 0.0 // Sum of duration (in minutes) for "Task" items
-	totalTaskCount := 0  // Count used for average calculations (same as totalTasks)
+func AnalyzeData(tasks []types.Task) types.Summary {
+	var summary types.Summary
+
+	projects := make(map[string]*types.ProjectSummary)
+	periods := newPeriodTotals()
+	currencies := make(map[string]*types.CurrencySummary)
 
 	for _, task := range tasks {
 		// Debug output
-		// log.Printf("Analisando: Type=%s, Value=%.2f, DurationMins=%.2f\n",
-		// 	task.Type, task.Value, task.DurationMins)
+		// log.Printf("Analisando: Type=%s, Value=%.2f, Elapsed=%s\n",
+		// 	task.Type, task.Value, task.Elapsed)
 
-		// Accumulate hours based on type
-		hours := task.DurationMins / 60
-		totalHours += hours
+		// Accumulate time based on type. Durations are whole seconds, so the
+		// sums are exact
+		elapsed := task.Elapsed
+		summary.TotalTime += elapsed
 
+		var bucket *types.Bucket
 		switch task.Type {
 		case "Task":
-			totalTasks++
-			totalTaskCount++                   // Increment task count for averages
-			totalTaskTime += task.DurationMins // Accumulate task time in minutes
-			totalTasksValue += task.Value
-			taskHours += hours
+			bucket = &summary.Tasks
 		case "Exceeded Time":
-			totalExceededTimeValue += task.Value
-			exceededTimeHours += hours
-		case "Mission Reward", "Operation": // Group known 'Other' types
-			totalOtherValue += task.Value
-			otherHours += hours
+			bucket = &summary.ExceededTime
+		case "Mission Reward", "Operation", "Adjustment": // Group known 'Other' types
+			bucket = &summary.Other
 		default: // Catch any unexpected types
 			log.Printf("Warning: Unknown task type encountered: %s", task.Type)
-			totalOtherValue += task.Value // Add value to 'Other'
-			otherHours += hours           // Add hours to 'Other'
+			bucket = &summary.Other // Add value and time to 'Other'
 		}
+		bucket.Count++
+		bucket.Time += elapsed
+		bucket.Value += task.Value
+
+		statusBucket := statusBucketFor(&summary.Status, task.Status)
+		statusBucket.Count++
+		statusBucket.Time += elapsed
+		statusBucket.Value += task.Value
+
+		addToProject(projects, task)
+		addToCurrency(currencies, task)
+		periods.add(task)
 	}
 
-	totalValue := totalTasksValue + totalExceededTimeValue + totalOtherValue
+	summary.TotalTasks = summary.Tasks.Count
+	summary.TotalValue = summary.Tasks.Value + summary.ExceededTime.Value + summary.Other.Value
+	summary.Status.Confirmed = summary.Status.Paid.Value + summary.Status.Approved.Value
+	summary.Status.Expected = summary.Status.Confirmed + summary.Status.Pending.Value
 
 	// Calculate averages
-	averageHourlyRate := 0.0
-	if totalHours > 0 {
+	if summary.TotalTime > 0 {
 		// Average hourly rate considers value from Task and Exceeded Time, divided by total hours
-		averageHourlyRate = (totalTasksValue + totalExceededTimeValue) / totalHours
+		summary.AverageHourlyRate = types.MoneyFromFloat((summary.Tasks.Value + summary.ExceededTime.Value).Float() / summary.TotalTime.Hours())
+	}
+
+	// Average time per task and average value per task
+	if summary.Tasks.Count > 0 {
+		summary.AvgTimePerTask = types.AverageDuration(summary.Tasks.Time, summary.Tasks.Count)
+		summary.AvgValuePerTask = types.MoneyFromFloat(summary.Tasks.Value.Float() / float64(summary.Tasks.Count))
+	}
+
+	summary.Projects = summarizeProjects(projects)
+	summary.Daily = periods.daily.summaries()
+	summary.Weekly = periods.weekly.summaries()
+	summary.Monthly = periods.monthly.summaries()
+	summary.UndatedItems = periods.undated
+	summary.Currencies, summary.MixedCurrencies = summarizeCurrencies(currencies)
+	if summary.MixedCurrencies {
+		log.Printf("Warning: tasks are in %d different currencies; totals add them together unconverted", len(summary.Currencies))
+	}
+
+	return summary
+}
+
+// statusBucketFor returns the status bucket a task belongs in. Tasks with no or
+// an unrecognised status are treated as pending, since they are not confirmed.
+func statusBucketFor(totals *types.StatusTotals, status string) *types.Bucket {
+	switch status {
+	case types.StatusPaid:
+		return &totals.Paid
+	case types.StatusApproved:
+		return &totals.Approved
+	case types.StatusRejected:
+		return &totals.Rejected
+	default:
+		return &totals.Pending
+	}
+}
+
+// addToProject accumulates a task into the summary for its category.
+func addToProject(projects map[string]*types.ProjectSummary, task types.Task) {
+	name := strings.TrimSpace(task.Category)
+	project, ok := projects[name]
+	if !ok {
+		project = &types.ProjectSummary{Name: name}
+		projects[name] = project
 	}
 
-	// Average time per task (in minutes)
-	avgTimePerTask := 0.0
-	if totalTaskCount > 0 {
-		avgTimePerTask = totalTaskTime / float64(totalTaskCount)
+	project.ItemCount++
+	project.TotalTime += task.Elapsed
+	project.Value += task.Value
+	switch task.Type {
+	case "Task":
+		project.TaskCount++
+		project.TaskTime += task.Elapsed
+	case "Exceeded Time":
+		project.ExceededTime += task.Elapsed
 	}
+}
 
-	// Average value per task
-	avgValuePerTask := 0.0
-	if totalTaskCount > 0 {
-		avgValuePerTask = totalTasksValue / float64(totalTaskCount)
+// summarizeProjects finalizes the per-project averages and returns the
+// projects sorted by value, highest first.
+func summarizeProjects(projects map[string]*types.ProjectSummary) []types.ProjectSummary {
+	result := make([]types.ProjectSummary, 0, len(projects))
+	for _, project := range projects {
+		if project.TotalTime > 0 {
+			project.EffectiveRate = types.MoneyFromFloat(project.Value.Float() / project.TotalTime.Hours())
+		}
+		project.AvgTimePerTask = types.AverageDuration(project.TaskTime, project.TaskCount)
+		result = append(result, *project)
 	}
 
-	return map[string]interface{}{
-		"TotalTasks":        totalTasks,
-		"TotalHours":        totalHours, // Raw float value
-		"TotalValue":        totalValue,
-		"TasksValue":        totalTasksValue,
-		"ExceededTimeValue": totalExceededTimeValue,
-		"OtherValue":        totalOtherValue,
-		"AverageHourlyRate": averageHourlyRate,
-		// Detailed hour breakdowns (raw float values)
-		"TaskHours":         taskHours,
-		"ExceededTimeHours": exceededTimeHours,
-		"OtherHours":        otherHours,
-		// Average metrics (raw float values)
-		"AvgTimePerTask":  avgTimePerTask, // In minutes
-		"AvgValuePerTask": avgValuePerTask,
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Value != result[j].Value {
+			return result[i].Value > result[j].Value
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// addToCurrency accumulates a task into the totals for its currency.
+func addToCurrency(currencies map[string]*types.CurrencySummary, task types.Task) {
+	currency, ok := currencies[task.Currency]
+	if !ok {
+		currency = &types.CurrencySummary{Currency: task.Currency}
+		currencies[task.Currency] = currency
+	}
+	currency.ItemCount++
+	currency.Time += task.Elapsed
+	currency.Value += task.Value
+}
+
+// summarizeCurrencies returns the per-currency totals sorted by value, highest
+// first, and whether more than one currency was named. Amounts written without
+// a currency are assumed to be in the same one as the rest.
+func summarizeCurrencies(currencies map[string]*types.CurrencySummary) ([]types.CurrencySummary, bool) {
+	result := make([]types.CurrencySummary, 0, len(currencies))
+	named := 0
+	for code, currency := range currencies {
+		if code != "" {
+			named++
+		}
+		result = append(result, *currency)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Value != result[j].Value {
+			return result[i].Value > result[j].Value
+		}
+		return result[i].Currency < result[j].Currency
+	})
+	return result, named > 1
+}
+
+// FilterByProject returns the tasks whose Category matches one of projects,
+// ignoring case. An empty list leaves the tasks unfiltered.
+func FilterByProject(tasks []types.Task, projects []string) []types.Task {
+	if len(projects) == 0 {
+		return tasks
+	}
+	var filtered []types.Task
+	for _, task := range tasks {
+		for _, project := range projects {
+			if strings.EqualFold(strings.TrimSpace(task.Category), strings.TrimSpace(project)) {
+				filtered = append(filtered, task)
+				break
+			}
+		}
 	}
+	return filtered
 }
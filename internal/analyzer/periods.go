@@ -0,0 +1,111 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// periodAccumulator collects per-period totals for one granularity.
+type periodAccumulator map[string]*types.PeriodSummary
+
+// add accumulates a task into the period identified by key.
+func (acc periodAccumulator) add(key string, start time.Time, task types.Task) {
+	period, ok := acc[key]
+	if !ok {
+		period = &types.PeriodSummary{Period: key, Start: start}
+		acc[key] = period
+	}
+	period.ItemCount++
+	period.Time += task.Elapsed
+	period.Value += task.Value
+}
+
+// summaries finalizes effective rates and returns the periods oldest first.
+func (acc periodAccumulator) summaries() []types.PeriodSummary {
+	result := make([]types.PeriodSummary, 0, len(acc))
+	for _, period := range acc {
+		if period.Time > 0 {
+			period.EffectiveRate = types.MoneyFromFloat(period.Value.Float() / period.Time.Hours())
+		}
+		result = append(result, *period)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Start.Before(result[j].Start)
+	})
+	return result
+}
+
+// periodTotals groups tasks into daily, ISO-weekly and monthly totals.
+type periodTotals struct {
+	daily, weekly, monthly periodAccumulator
+	undated                int
+}
+
+func newPeriodTotals() *periodTotals {
+	return &periodTotals{
+		daily:   make(periodAccumulator),
+		weekly:  make(periodAccumulator),
+		monthly: make(periodAccumulator),
+	}
+}
+
+// add places a task into its day, week and month.
+func (p *periodTotals) add(task types.Task) {
+	if task.WorkDate.IsZero() {
+		p.undated++
+		return
+	}
+	// Group by the calendar day the task was worked, dropping any time of day
+	day := time.Date(task.WorkDate.Year(), task.WorkDate.Month(), task.WorkDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	p.daily.add(day.Format("2006-01-02"), day, task)
+
+	year, week := day.ISOWeek()
+	weekStart := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7)) // Monday of the ISO week
+	p.weekly.add(fmt.Sprintf("%d-W%02d", year, week), weekStart, task)
+
+	monthStart := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	p.monthly.add(day.Format("2006-01"), monthStart, task)
+}
+
+// SortByDate returns a copy of tasks in chronological order of WorkDate.
+// Tasks without a readable date keep their relative order at the end.
+func SortByDate(tasks []types.Task) []types.Task {
+	sorted := make([]types.Task, len(tasks))
+	copy(sorted, tasks)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].WorkDate, sorted[j].WorkDate
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero() && b.IsZero()
+		}
+		return a.Before(b)
+	})
+	return sorted
+}
+
+// FilterByDate returns the tasks whose WorkDate falls within [from, to], both
+// inclusive by calendar day. A zero bound leaves that side open. When any bound
+// is set, tasks without a readable date are left out.
+func FilterByDate(tasks []types.Task, from, to time.Time) []types.Task {
+	if from.IsZero() && to.IsZero() {
+		return tasks
+	}
+	var filtered []types.Task
+	for _, task := range tasks {
+		if task.WorkDate.IsZero() {
+			continue
+		}
+		day := task.WorkDate.Format("2006-01-02")
+		if !from.IsZero() && day < from.Format("2006-01-02") {
+			continue
+		}
+		if !to.IsZero() && day > to.Format("2006-01-02") {
+			continue
+		}
+		filtered = append(filtered, task)
+	}
+	return filtered
+}
@@ -0,0 +1,264 @@
+// Package config loads the server settings from defaults, an optional JSON
+// config file, environment variables and command-line flags, in increasing
+// order of precedence.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/i18n"
+)
+
+// Config holds the server settings. The json tags are the config file keys.
+type Config struct {
+	Port            string        `json:"port"`
+	MaxUploadMB     int           `json:"maxUploadMB"`     // Limit for form uploads and API request bodies
+	TemplateDir     string        `json:"templateDir"`     // Directory with a custom index.html, empty for the embedded one
+	StaticDir       string        `json:"staticDir"`       // Directory served under /static/, empty for the embedded assets
+	ShutdownTimeout time.Duration `json:"-"`               // Read from the file as a string such as "5s"
+	Locale          string        `json:"locale"`          // Interface language when the browser asks for none we support
+	CurrencySymbol  string        `json:"currencySymbol"`  // Shown before every amount on the results page
+	HourLabel       string        `json:"hourLabel"`       // e.g. "horas" in "7,50 horas (7h 30min)", empty for the locale's word
+	HistoryFile     string        `json:"historyFile"`     // "off" disables the task history
+	ResultsDir      string        `json:"resultsDir"`      // "off" disables saved result permalinks
+	FXRatesFile     string        `json:"fxRatesFile"`     // CSV or JSON exchange rate table, empty to disable conversion
+	ReportCurrency  string        `json:"reportCurrency"`  // ISO 4217 code totals are converted into
+	DefaultCurrency string        `json:"defaultCurrency"` // Currency of amounts written without one, for conversion
+	ProfilesDir     string        `json:"profilesDir"`     // Directory of CSV mapping profiles, empty for none
+}
+
+// fileConfig is the config file layout: Config with a readable shutdown timeout.
+type fileConfig struct {
+	*Config
+	ShutdownTimeout string `json:"shutdownTimeout"`
+}
+
+// Default returns the settings used when nothing else is configured.
+func Default() Config {
+	return Config{
+		Port:            "8080",
+		MaxUploadMB:     10,
+		ShutdownTimeout: 5 * time.Second,
+		Locale:          "pt-BR",
+		CurrencySymbol:  "$",
+		HistoryFile:     "task-history.json",
+		ResultsDir:      "saved-results",
+		ReportCurrency:  "BRL",
+		DefaultCurrency: "USD",
+	}
+}
+
+// MaxUploadBytes returns the upload limit in bytes.
+func (c Config) MaxUploadBytes() int64 {
+	return int64(c.MaxUploadMB) << 20
+}
+
+// Load builds the configuration from args (without the program name) and
+// getenv, usually os.Args[1:] and os.Getenv. The config file is taken from
+// -config or CONFIG_FILE; flags override environment variables, which
+// override the file, which overrides the defaults.
+func Load(args []string, getenv func(string) string) (Config, error) {
+	// First pass only finds -config, and rejects bad flags before any file is read
+	var path string
+	scratch := Default()
+	if err := newFlagSet(&scratch, &path).Parse(args); err != nil {
+		return Config{}, err
+	}
+	if path == "" {
+		path = getenv("CONFIG_FILE")
+	}
+
+	cfg := Default()
+	if path != "" {
+		if err := loadFile(&cfg, path); err != nil {
+			return Config{}, err
+		}
+	}
+	if err := applyEnv(&cfg, getenv); err != nil {
+		return Config{}, err
+	}
+	// Flags default to the values loaded so far, so only those given override them
+	if err := newFlagSet(&cfg, &path).Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// newFlagSet binds the command-line flags to cfg, using its current values as defaults.
+func newFlagSet(cfg *Config, path *string) *flag.FlagSet {
+	flags := flag.NewFlagSet("server", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.StringVar(path, "config", *path, "JSON config file (env CONFIG_FILE)")
+	flags.StringVar(&cfg.Port, "port", cfg.Port, "HTTP port (env PORT)")
+	flags.IntVar(&cfg.MaxUploadMB, "max-upload-mb", cfg.MaxUploadMB, "upload and API body limit in MB (env MAX_UPLOAD_MB)")
+	flags.StringVar(&cfg.TemplateDir, "template-dir", cfg.TemplateDir, "directory with a custom index.html (env TEMPLATE_DIR)")
+	flags.StringVar(&cfg.StaticDir, "static-dir", cfg.StaticDir, "directory served under /static/ (env STATIC_DIR)")
+	flags.DurationVar(&cfg.ShutdownTimeout, "shutdown-timeout", cfg.ShutdownTimeout, "graceful shutdown timeout (env SHUTDOWN_TIMEOUT)")
+	flags.StringVar(&cfg.Locale, "locale", cfg.Locale, "default interface language, pt-BR or en (env LOCALE)")
+	flags.StringVar(&cfg.CurrencySymbol, "currency", cfg.CurrencySymbol, "currency symbol shown before amounts (env CURRENCY_SYMBOL)")
+	flags.StringVar(&cfg.HourLabel, "hour-label", cfg.HourLabel, "label for hour totals instead of the locale's (env HOUR_LABEL)")
+	flags.StringVar(&cfg.HistoryFile, "history-file", cfg.HistoryFile, "task history file, or off (env HISTORY_FILE)")
+	flags.StringVar(&cfg.ResultsDir, "results-dir", cfg.ResultsDir, "saved results directory, or off (env RESULTS_DIR)")
+	flags.StringVar(&cfg.FXRatesFile, "fx-rates", cfg.FXRatesFile, "CSV or JSON exchange rate table to convert totals with (env FX_RATES_FILE)")
+	flags.StringVar(&cfg.ReportCurrency, "report-currency", cfg.ReportCurrency, "currency code totals are converted into (env REPORT_CURRENCY)")
+	flags.StringVar(&cfg.DefaultCurrency, "default-currency", cfg.DefaultCurrency, "currency code of amounts written without one (env DEFAULT_CURRENCY)")
+	flags.StringVar(&cfg.ProfilesDir, "profiles-dir", cfg.ProfilesDir, "directory of CSV column mapping profiles (env PROFILES_DIR)")
+	return flags
+}
+
+// Usage prints the server flags and their defaults to w.
+func Usage(w io.Writer) {
+	var path string
+	cfg := Default()
+	flags := newFlagSet(&cfg, &path)
+	flags.SetOutput(w)
+	fmt.Fprintln(w, "Usage of server:")
+	flags.PrintDefaults()
+}
+
+// loadFile reads a JSON config file over cfg. Keys that are missing keep their
+// current value; unknown keys are an error so that typos are not ignored.
+func loadFile(cfg *Config, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("config file: %w", err)
+	}
+	defer f.Close()
+
+	fc := fileConfig{Config: cfg}
+	decoder := json.NewDecoder(f)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&fc); err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	if fc.ShutdownTimeout != "" {
+		timeout, err := time.ParseDuration(fc.ShutdownTimeout)
+		if err != nil {
+			return fmt.Errorf("config file %s: shutdownTimeout: %w", path, err)
+		}
+		cfg.ShutdownTimeout = timeout
+	}
+	return nil
+}
+
+// applyEnv overrides cfg with any of the environment variables that are set.
+func applyEnv(cfg *Config, getenv func(string) string) error {
+	stringVars := map[string]*string{
+		"PORT":             &cfg.Port,
+		"TEMPLATE_DIR":     &cfg.TemplateDir,
+		"STATIC_DIR":       &cfg.StaticDir,
+		"LOCALE":           &cfg.Locale,
+		"CURRENCY_SYMBOL":  &cfg.CurrencySymbol,
+		"HOUR_LABEL":       &cfg.HourLabel,
+		"HISTORY_FILE":     &cfg.HistoryFile,
+		"RESULTS_DIR":      &cfg.ResultsDir,
+		"FX_RATES_FILE":    &cfg.FXRatesFile,
+		"REPORT_CURRENCY":  &cfg.ReportCurrency,
+		"DEFAULT_CURRENCY": &cfg.DefaultCurrency,
+		"PROFILES_DIR":     &cfg.ProfilesDir,
+	}
+	for name, field := range stringVars {
+		if value := getenv(name); value != "" {
+			*field = value
+		}
+	}
+
+	if value := getenv("MAX_UPLOAD_MB"); value != "" {
+		mb, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("MAX_UPLOAD_MB: %w", err)
+		}
+		cfg.MaxUploadMB = mb
+	}
+	if value := getenv("SHUTDOWN_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("SHUTDOWN_TIMEOUT: %w", err)
+		}
+		cfg.ShutdownTimeout = timeout
+	}
+	return nil
+}
+
+// Validate reports the first setting that the server could not start with.
+func (c Config) Validate() error {
+	port, err := strconv.Atoi(c.Port)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("port %q is not a number between 1 and 65535", c.Port)
+	}
+	if c.MaxUploadMB < 1 {
+		return fmt.Errorf("max upload size must be at least 1 MB, got %d", c.MaxUploadMB)
+	}
+	if c.ShutdownTimeout <= 0 {
+		return fmt.Errorf("shutdown timeout must be positive, got %s", c.ShutdownTimeout)
+	}
+	if _, ok := i18n.Lookup(c.Locale); !ok {
+		return fmt.Errorf("unsupported locale %q", c.Locale)
+	}
+	if c.HistoryFile == "" {
+		return errors.New("history file must not be empty, use \"off\" to disable the history")
+	}
+	if c.ResultsDir == "" {
+		return errors.New("results directory must not be empty, use \"off\" to disable saved results")
+	}
+	if !isCurrencyCode(c.ReportCurrency) {
+		return fmt.Errorf("report currency %q is not a three-letter ISO 4217 code such as BRL", c.ReportCurrency)
+	}
+	if !isCurrencyCode(c.DefaultCurrency) {
+		return fmt.Errorf("default currency %q is not a three-letter ISO 4217 code such as USD", c.DefaultCurrency)
+	}
+	if c.FXRatesFile != "" {
+		if _, err := os.Stat(c.FXRatesFile); err != nil {
+			return fmt.Errorf("exchange rate file: %w", err)
+		}
+	}
+	if c.ProfilesDir != "" {
+		info, err := os.Stat(c.ProfilesDir)
+		if err != nil {
+			return fmt.Errorf("profiles directory: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("profiles directory %s is not a directory", c.ProfilesDir)
+		}
+	}
+	if c.TemplateDir != "" {
+		if _, err := os.Stat(filepath.Join(c.TemplateDir, "index.html")); err != nil {
+			return fmt.Errorf("template directory: %w", err)
+		}
+	}
+	if c.StaticDir != "" {
+		info, err := os.Stat(c.StaticDir)
+		if err != nil {
+			return fmt.Errorf("static directory: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("static directory %s is not a directory", c.StaticDir)
+		}
+	}
+	return nil
+}
+
+// isCurrencyCode reports whether s is three upper-case letters.
+func isCurrencyCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,274 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// Export formats
+const (
+	FormatCSV        = "csv"
+	FormatSummaryCSV = "summary-csv" // Per-project summary with a total row
+	FormatJSON       = "json"
+	FormatMarkdown   = "md"
+)
+
+// CSVHeader is the canonical column layout written by WriteCSV. Every column
+// except isoDate and durationMins is read back by parser.ParseCSV.
+var CSVHeader = []string{"date", "isoDate", "id", "category", "duration", "durationMins", "rate", "value", "currency", "type", "status"}
+
+// Report is the document written by WriteJSON.
+type Report struct {
+	Summary types.Summary `json:"summary"`
+	Tasks   []types.Task  `json:"tasks"`
+}
+
+// ContentType returns the MIME type for an export format.
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV, FormatSummaryCSV:
+		return "text/csv; charset=utf-8"
+	case FormatJSON:
+		return "application/json; charset=utf-8"
+	case FormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	}
+	return "application/octet-stream"
+}
+
+// Write writes tasks and summary in the given export format.
+func Write(w io.Writer, format string, summary types.Summary, tasks []types.Task) error {
+	switch format {
+	case FormatCSV:
+		return WriteCSV(w, tasks)
+	case FormatSummaryCSV:
+		return WriteSummaryCSV(w, summary)
+	case FormatJSON:
+		return WriteJSON(w, summary, tasks)
+	case FormatMarkdown:
+		return WriteMarkdown(w, summary, tasks)
+	}
+	return fmt.Errorf("unknown export format %q", format)
+}
+
+// WriteCSV writes the cleaned task list, with filled categories and
+// normalized types and statuses, as canonical CSV.
+func WriteCSV(w io.Writer, tasks []types.Task) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(CSVHeader); err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		isoDate := ""
+		if !task.WorkDate.IsZero() {
+			isoDate = task.WorkDate.Format("2006-01-02")
+		}
+		record := []string{
+			task.Date,
+			isoDate,
+			task.ID,
+			task.Category,
+			task.Duration,
+			fmt.Sprintf("%.2f", task.Elapsed.Minutes()),
+			task.Rate.String(),
+			task.Value.String(),
+			task.Currency,
+			task.Type,
+			task.Status,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// SummaryCSVHeader is the column layout written by WriteSummaryCSV.
+var SummaryCSVHeader = []string{"project", "taskCount", "itemCount", "totalHours", "taskHours", "exceededTimeHours", "value", "effectiveRate", "avgTimePerTaskMins"}
+
+// WriteSummaryCSV writes one row per project followed by a "(total)" row
+// covering every item, for spreadsheets and scripts that want the summary
+// rather than the task list.
+func WriteSummaryCSV(w io.Writer, summary types.Summary) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(SummaryCSVHeader); err != nil {
+		return err
+	}
+	for _, p := range summary.Projects {
+		record := []string{
+			p.Name,
+			fmt.Sprintf("%d", p.TaskCount),
+			fmt.Sprintf("%d", p.ItemCount),
+			fmt.Sprintf("%.2f", p.TotalTime.Hours()),
+			fmt.Sprintf("%.2f", p.TaskTime.Hours()),
+			fmt.Sprintf("%.2f", p.ExceededTime.Hours()),
+			p.Value.String(),
+			p.EffectiveRate.String(),
+			fmt.Sprintf("%.2f", p.AvgTimePerTask.Minutes()),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	var totalRate types.Money
+	if summary.TotalTime > 0 {
+		totalRate = types.MoneyFromFloat(summary.TotalValue.Float() / summary.TotalTime.Hours())
+	}
+	total := []string{
+		"(total)",
+		fmt.Sprintf("%d", summary.TotalTasks),
+		fmt.Sprintf("%d", summary.Tasks.Count+summary.ExceededTime.Count+summary.Other.Count),
+		fmt.Sprintf("%.2f", summary.TotalTime.Hours()),
+		fmt.Sprintf("%.2f", summary.Tasks.Time.Hours()),
+		fmt.Sprintf("%.2f", summary.ExceededTime.Time.Hours()),
+		summary.TotalValue.String(),
+		totalRate.String(),
+		fmt.Sprintf("%.2f", summary.AvgTimePerTask.Minutes()),
+	}
+	if err := writer.Write(total); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteJSON writes the summary and the cleaned task list as an indented JSON Report.
+func WriteJSON(w io.Writer, summary types.Summary, tasks []types.Task) error {
+	if tasks == nil {
+		tasks = []types.Task{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(Report{Summary: summary, Tasks: tasks})
+}
+
+// WriteMarkdown writes a human-readable report with the summary, the
+// breakdowns and the cleaned task list.
+func WriteMarkdown(w io.Writer, summary types.Summary, tasks []types.Task) error {
+	var b strings.Builder
+	code := SummaryCurrency(summary)
+
+	b.WriteString("# Task Report\n\n")
+	if summary.MixedCurrencies {
+		b.WriteString("> **Warning:** the tasks are in more than one currency. Totals, averages and breakdowns add them together unconverted; see By Currency.\n\n")
+	}
+	b.WriteString("## Overview\n\n")
+	b.WriteString("| Metric | Value |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Tasks | %d |\n", summary.TotalTasks)
+	fmt.Fprintf(&b, "| Hours | %.2f |\n", summary.TotalTime.Hours())
+	fmt.Fprintf(&b, "| Total value | %s |\n", markdownAmount(summary.TotalValue, code))
+	fmt.Fprintf(&b, "| Average hourly rate | %s/hr |\n", markdownAmount(summary.AverageHourlyRate, code))
+	fmt.Fprintf(&b, "| Average time per task | %.2f min |\n", summary.AvgTimePerTask.Minutes())
+	fmt.Fprintf(&b, "| Average value per task | %s |\n", markdownAmount(summary.AvgValuePerTask, code))
+
+	if summary.MixedCurrencies {
+		b.WriteString("\n## By Currency\n\n")
+		b.WriteString("| Currency | Items | Hours | Value |\n|---|---:|---:|---:|\n")
+		for _, c := range summary.Currencies {
+			fmt.Fprintf(&b, "| %s | %d | %.2f | %s |\n", markdownCell(c.Currency), c.ItemCount, c.Time.Hours(), markdownAmount(c.Value, c.Currency))
+		}
+	}
+
+	b.WriteString("\n## By Type\n\n")
+	b.WriteString("| Type | Items | Hours | Value |\n|---|---:|---:|---:|\n")
+	writeBucketRow(&b, "Task", summary.Tasks, code)
+	writeBucketRow(&b, "Exceeded Time", summary.ExceededTime, code)
+	writeBucketRow(&b, "Other", summary.Other, code)
+
+	b.WriteString("\n## By Status\n\n")
+	b.WriteString("| Status | Items | Hours | Value |\n|---|---:|---:|---:|\n")
+	writeBucketRow(&b, "Paid", summary.Status.Paid, code)
+	writeBucketRow(&b, "Approved", summary.Status.Approved, code)
+	writeBucketRow(&b, "Pending", summary.Status.Pending, code)
+	writeBucketRow(&b, "Rejected", summary.Status.Rejected, code)
+	fmt.Fprintf(&b, "\nConfirmed: %s. Expected (confirmed + pending): %s.\n",
+		markdownAmount(summary.Status.Confirmed, code), markdownAmount(summary.Status.Expected, code))
+
+	if len(summary.Projects) > 0 {
+		b.WriteString("\n## By Project\n\n")
+		b.WriteString("| Project | Tasks | Hours | Task Hours | Exceeded Hours | Value | Rate | Avg Time |\n")
+		b.WriteString("|---|---:|---:|---:|---:|---:|---:|---:|\n")
+		for _, p := range summary.Projects {
+			fmt.Fprintf(&b, "| %s | %d | %.2f | %.2f | %.2f | %s | %s/hr | %.2f min |\n",
+				markdownCell(p.Name), p.TaskCount, p.TotalTime.Hours(), p.TaskTime.Hours(), p.ExceededTime.Hours(),
+				markdownAmount(p.Value, code), markdownAmount(p.EffectiveRate, code), p.AvgTimePerTask.Minutes())
+		}
+	}
+
+	writePeriodTable(&b, "By Month", summary.Monthly, code)
+	writePeriodTable(&b, "By Week", summary.Weekly, code)
+	writePeriodTable(&b, "By Day", summary.Daily, code)
+
+	if len(tasks) > 0 {
+		b.WriteString("\n## Tasks\n\n")
+		b.WriteString("| Date | ID | Project | Duration | Rate | Value | Type | Status |\n")
+		b.WriteString("|---|---|---|---|---:|---:|---|---|\n")
+		for _, t := range tasks {
+			taskCode := t.Currency
+			if taskCode == "" {
+				taskCode = code
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s/hr | %s | %s | %s |\n",
+				markdownCell(t.Date), markdownCell(t.ID), markdownCell(t.Category), markdownCell(t.Duration),
+				markdownAmount(t.Rate, taskCode), markdownAmount(t.Value, taskCode), markdownCell(t.Type), markdownCell(t.Status))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeBucketRow writes one row of a type or status table.
+func writeBucketRow(b *strings.Builder, label string, bucket types.Bucket, code string) {
+	fmt.Fprintf(b, "| %s | %d | %.2f | %s |\n", label, bucket.Count, bucket.Time.Hours(), markdownAmount(bucket.Value, code))
+}
+
+// writePeriodTable writes a daily, weekly or monthly table if it has rows.
+func writePeriodTable(b *strings.Builder, title string, periods []types.PeriodSummary, code string) {
+	if len(periods) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n", title)
+	b.WriteString("| Period | Items | Hours | Value | Rate |\n|---|---:|---:|---:|---:|\n")
+	for _, p := range periods {
+		fmt.Fprintf(b, "| %s | %d | %.2f | %s | %s/hr |\n", p.Period, p.ItemCount, p.Time.Hours(), markdownAmount(p.Value, code), markdownAmount(p.EffectiveRate, code))
+	}
+}
+
+// SummaryCurrency returns the ISO 4217 code all named amounts in the summary
+// share, or "" if none was named or they are in more than one currency.
+func SummaryCurrency(summary types.Summary) string {
+	if summary.MixedCurrencies {
+		return ""
+	}
+	for _, c := range summary.Currencies {
+		if c.Currency != "" {
+			return c.Currency
+		}
+	}
+	return ""
+}
+
+// markdownAmount writes an amount as "$12.50" for US dollars or amounts with
+// no currency, and as "12.50 EUR" otherwise.
+func markdownAmount(m types.Money, code string) string {
+	if code == "" || code == "USD" {
+		return "$" + m.String()
+	}
+	return m.String() + " " + code
+}
+
+// markdownCell escapes pipes and newlines so a value stays inside its table cell.
+func markdownCell(s string) string {
+	if s == "" {
+		return "-"
+	}
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,230 @@
+// Package fx converts task values between currencies using a local,
+// user-supplied table of exchange rates by date. It never fetches rates over
+// the network.
+package fx
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/parser"
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// Table holds exchange rates by currency pair and date.
+type Table struct {
+	rates map[pair][]datedRate // Oldest first
+}
+
+// pair is a conversion direction: 1 From = rate To.
+type pair struct{ from, to string }
+
+type datedRate struct {
+	date time.Time
+	rate float64
+}
+
+// entry is one row of a rate file, in either format.
+type entry struct {
+	Date string  `json:"date"`
+	From string  `json:"from"`
+	To   string  `json:"to"`
+	Rate float64 `json:"rate"`
+}
+
+// Load reads a rate table from a .json file, or from CSV otherwise.
+//
+// CSV files need a header with the columns date, from, to and rate, in any
+// order:
+//
+//	date,from,to,rate
+//	2025-03-28,USD,BRL,5.74
+//
+// JSON files hold an array of objects with the same keys. A rate means
+// 1 from = rate to, and also serves the opposite direction.
+func Load(path string) (*Table, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading exchange rates: %w", err)
+	}
+	var entries []entry
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &entries)
+	} else {
+		entries, err = readCSV(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("exchange rates %s: %w", path, err)
+	}
+	table, err := newTable(entries)
+	if err != nil {
+		return nil, fmt.Errorf("exchange rates %s: %w", path, err)
+	}
+	return table, nil
+}
+
+// readCSV reads rate entries from CSV with a date,from,to,rate header.
+func readCSV(r io.Reader) ([]entry, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range []string{"date", "from", "to", "rate"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q in header", name)
+		}
+	}
+
+	var entries []entry
+	for i, record := range records[1:] {
+		rate, err := strconv.ParseFloat(strings.TrimSpace(record[columns["rate"]]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid rate %q", i+2, record[columns["rate"]])
+		}
+		entries = append(entries, entry{
+			Date: record[columns["date"]],
+			From: record[columns["from"]],
+			To:   record[columns["to"]],
+			Rate: rate,
+		})
+	}
+	return entries, nil
+}
+
+// newTable validates entries and indexes them by pair and date.
+func newTable(entries []entry) (*Table, error) {
+	t := &Table{rates: make(map[pair][]datedRate)}
+	for i, e := range entries {
+		date, err := parser.ParseDate(strings.TrimSpace(e.Date))
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %v", i+1, err)
+		}
+		from := strings.ToUpper(strings.TrimSpace(e.From))
+		to := strings.ToUpper(strings.TrimSpace(e.To))
+		if !isCode(from) || !isCode(to) {
+			return nil, fmt.Errorf("entry %d: currencies must be ISO 4217 codes such as USD, got %q and %q", i+1, e.From, e.To)
+		}
+		if e.Rate <= 0 {
+			return nil, fmt.Errorf("entry %d: rate must be positive, got %v", i+1, e.Rate)
+		}
+		p := pair{from, to}
+		t.rates[p] = append(t.rates[p], datedRate{date, e.Rate})
+	}
+	for _, rates := range t.rates {
+		sort.SliceStable(rates, func(i, j int) bool { return rates[i].date.Before(rates[j].date) })
+	}
+	return t, nil
+}
+
+// isCode reports whether s looks like an ISO 4217 code.
+func isCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Len returns the number of rates in the table.
+func (t *Table) Len() int {
+	n := 0
+	for _, rates := range t.rates {
+		n += len(rates)
+	}
+	return n
+}
+
+// Rate returns the rate to convert from one currency to another on date: the
+// latest one published on or before it, so weekends and holidays use the last
+// working day's rate. The opposite pair is used, inverted, if the table only
+// has that direction. ok is false if no rate is dated on or before date.
+func (t *Table) Rate(from, to string, date time.Time) (rate float64, ok bool) {
+	if from == to {
+		return 1, true
+	}
+	if rate, ok := latest(t.rates[pair{from, to}], date); ok {
+		return rate, true
+	}
+	if rate, ok := latest(t.rates[pair{to, from}], date); ok {
+		return 1 / rate, true
+	}
+	return 0, false
+}
+
+// latest returns the last rate dated on or before date.
+func latest(rates []datedRate, date time.Time) (float64, bool) {
+	i := sort.Search(len(rates), func(i int) bool { return rates[i].date.After(date) })
+	if i == 0 {
+		return 0, false
+	}
+	return rates[i-1].rate, true
+}
+
+// Convert adds up the values of tasks in the currency to, converting each
+// task at the rate for its work date. Tasks that name no currency are taken
+// to be in assumed. Tasks already in to are added as they are. Other tasks
+// without a work date, or without a rate for their currency and date, are
+// left out of the total and counted as unconverted.
+func (t *Table) Convert(tasks []types.Task, to, assumed string) types.Conversion {
+	conversion := types.Conversion{Currency: to}
+	missing := map[types.MissingRate]bool{}
+	for _, task := range tasks {
+		from := task.Currency
+		if from == "" {
+			from = assumed
+		}
+		if from == to { // No rate needed, even without a work date
+			conversion.Converted++
+			conversion.Value += task.Value
+			continue
+		}
+		if from == "" || task.WorkDate.IsZero() {
+			conversion.Unconverted++
+			missing[types.MissingRate{Currency: from}] = true
+			continue
+		}
+		rate, ok := t.Rate(from, to, task.WorkDate)
+		if !ok {
+			conversion.Unconverted++
+			missing[types.MissingRate{Currency: from, Date: task.WorkDate}] = true
+			continue
+		}
+		conversion.Converted++
+		conversion.Value += types.MoneyFromFloat(task.Value.Float() * rate)
+	}
+
+	for key := range missing {
+		conversion.MissingRates = append(conversion.MissingRates, key)
+	}
+	sort.Slice(conversion.MissingRates, func(i, j int) bool {
+		a, b := conversion.MissingRates[i], conversion.MissingRates[j]
+		if a.Currency != b.Currency {
+			return a.Currency < b.Currency
+		}
+		return a.Date.Before(b.Date)
+	})
+	return conversion
+}
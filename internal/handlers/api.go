@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/config"
+	"github.com/erickgnclvs/go-task-viewer/internal/parser"
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// contentTypeFormats maps request media types onto parser formats. Anything
+// else (including text/plain) is sniffed with parser.DetectFormat.
+var contentTypeFormats = map[string]string{
+	"text/csv":                  parser.FormatCSV,
+	"text/tab-separated-values": parser.FormatTSV,
+	"application/json":          parser.FormatJSON,
+	"application/x-ndjson":      parser.FormatJSON,
+}
+
+// apiAnalyzeResponse is the body returned by POST /api/v1/analyze.
+type apiAnalyzeResponse struct {
+	Format      parser.Detection   `json:"format"`
+	Summary     types.Summary      `json:"summary"`
+	Tasks       []types.Task       `json:"tasks"`
+	Diagnostics []types.Diagnostic `json:"diagnostics"`
+}
+
+// apiError is the body returned with any non-2xx status.
+type apiError struct {
+	Error       string             `json:"error"`
+	Diagnostics []types.Diagnostic `json:"diagnostics,omitempty"`
+}
+
+// APIAnalyzeHandler analyzes CSV, text or JSON sent as the raw request body and
+// returns the summary, breakdowns, parsed tasks and diagnostics as JSON.
+// The format is taken from the "format" query parameter, then the Content-Type
+// header, and is detected from the payload otherwise. The "profile" query
+// parameter names a mapping profile for CSV columns. Ambiguous amounts are read
+// in the number style of the request's language (?lang= or Accept-Language).
+// The body is limited to the same size as form uploads.
+func APIAnalyzeHandler(cfg config.Config, profiles parser.Profiles) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeJSON(w, http.StatusMethodNotAllowed, apiError{Error: "method not allowed, use POST"})
+			return
+		}
+
+		format := r.URL.Query().Get("format")
+		if format != "" && !parser.IsFormat(format) {
+			writeJSON(w, http.StatusBadRequest, apiError{Error: "unknown format '" + format + "'"})
+			return
+		}
+		var profile *parser.Profile
+		if name := r.URL.Query().Get("profile"); name != "" {
+			if profile = profiles.Lookup(name); profile == nil {
+				writeJSON(w, http.StatusBadRequest, apiError{Error: "unknown profile '" + name + "'"})
+				return
+			}
+		}
+		if format == "" {
+			if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil {
+				format = contentTypeFormats[strings.ToLower(mediaType)]
+			}
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, cfg.MaxUploadBytes()))
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				writeJSON(w, http.StatusRequestEntityTooLarge, apiError{Error: "request body too large"})
+				return
+			}
+			log.Printf("Error reading API request body: %v", err)
+			writeJSON(w, http.StatusBadRequest, apiError{Error: "could not read request body"})
+			return
+		}
+		input, encoding := parser.DecodeInput(body)
+		if strings.TrimSpace(input) == "" {
+			writeJSON(w, http.StatusBadRequest, apiError{Error: "request body is empty"})
+			return
+		}
+		log.Printf("[DEBUG] API request body is %s", encoding)
+
+		result := runAnalysis(input, format, profile, numberStyle(localeFor(w, r, cfg)))
+		if len(result.Tasks) == 0 {
+			writeJSON(w, http.StatusUnprocessableEntity, apiError{
+				Error:       "no tasks could be parsed from the request body",
+				Diagnostics: result.Diagnostics,
+			})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, apiAnalyzeResponse{
+			Format:      result.Detection,
+			Summary:     result.Summary,
+			Tasks:       result.Tasks,
+			Diagnostics: nonNilDiagnostics(result.Diagnostics),
+		})
+	}
+}
+
+// nonNilDiagnostics makes an empty diagnostics list encode as [] rather than null.
+func nonNilDiagnostics(diags []types.Diagnostic) []types.Diagnostic {
+	if diags == nil {
+		return []types.Diagnostic{}
+	}
+	return diags
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Error encoding JSON response: %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/config"
+	"github.com/erickgnclvs/go-task-viewer/internal/export"
+	"github.com/erickgnclvs/go-task-viewer/internal/parser"
+	"github.com/erickgnclvs/go-task-viewer/internal/store"
+)
+
+// exportExtensions maps export formats onto download file extensions.
+var exportExtensions = map[string]string{
+	export.FormatCSV:        "csv",
+	export.FormatSummaryCSV: "csv",
+	export.FormatJSON:       "json",
+	export.FormatMarkdown:   "md",
+}
+
+// ExportHandler re-runs the analysis on the posted input, the stored history
+// (source=history) or a saved result (source=result), and returns the cleaned
+// task list and summary as a CSV, JSON or Markdown download, or the per-project
+// summary alone as CSV.
+func ExportHandler(cfg config.Config, history *store.Store, results *store.Results, profiles parser.Profiles) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/", http.StatusSeeOther)
+			return
+		}
+
+		// Limit the upload size (MaxUploadMB, 10MB by default)
+		if err := r.ParseMultipartForm(cfg.MaxUploadBytes()); err != nil {
+			log.Printf("Error parsing multipart form: %v", err)
+			http.Error(w, "Error processing form data", http.StatusBadRequest)
+			return
+		}
+
+		format := r.FormValue("exportFormat")
+		ext, ok := exportExtensions[format]
+		if !ok {
+			http.Error(w, "Unknown export format", http.StatusBadRequest)
+			return
+		}
+
+		var result analysis
+		switch {
+		case r.FormValue("source") == "history" && history != nil:
+			from, to, err := parseDateRange(r.FormValue("from"), r.FormValue("to"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			result = historyAnalysis(history, from, to)
+		case r.FormValue("source") == "result" && results != nil:
+			var err error
+			result, err = loadAnalysis(results, r.FormValue("id"))
+			if errors.Is(err, store.ErrNotFound) {
+				http.NotFound(w, r)
+				return
+			}
+			if err != nil {
+				log.Printf("Error loading result for export: %v", err)
+				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+				return
+			}
+		default:
+			rawInputData, err := readRawInput(r)
+			if err != nil {
+				http.Error(w, "Error processing file upload", http.StatusInternalServerError)
+				return
+			}
+			result = runAnalysis(rawInputData, r.FormValue("inputSource"), profileFor(r, profiles), numberStyle(localeFor(w, r, cfg)))
+		}
+		if len(result.Tasks) == 0 {
+			http.Error(w, "No tasks to export", http.StatusUnprocessableEntity)
+			return
+		}
+		writeExport(w, format, ext, result)
+	}
+}
+
+// writeExport sends an analysis as a file download in the given format.
+func writeExport(w http.ResponseWriter, format, ext string, result analysis) {
+	name := "tarefas"
+	if format == export.FormatSummaryCSV {
+		name = "resumo"
+	}
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102"), ext)
+	w.Header().Set("Content-Type", export.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if err := export.Write(w, format, result.Summary, result.Tasks); err != nil {
+		log.Printf("Error writing %s export: %v", format, err)
+	}
+}
@@ -1,28 +1,52 @@
 package handlers
 
 import (
-	"fmt"
 	"html/template"
 	"io"
 	"log"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/erickgnclvs/go-task-viewer/internal/analyzer"
+	"github.com/erickgnclvs/go-task-viewer/internal/config"
+	"github.com/erickgnclvs/go-task-viewer/internal/export"
+	"github.com/erickgnclvs/go-task-viewer/internal/fx"
+	"github.com/erickgnclvs/go-task-viewer/internal/i18n"
 	"github.com/erickgnclvs/go-task-viewer/internal/parser"
+	"github.com/erickgnclvs/go-task-viewer/internal/store"
 	"github.com/erickgnclvs/go-task-viewer/internal/types"
 )
 
+// formatLabelKeys are the catalog keys for the display name of each parser format.
+var formatLabelKeys = map[string]string{
+	parser.FormatCSV:       "formatCSV",
+	parser.FormatTSV:       "formatTSV",
+	parser.FormatSemicolon: "formatSemi",
+	parser.FormatText:      "formatText",
+	parser.FormatLabeled:   "formatLabeled",
+	parser.FormatJSON:      "formatJSON",
+}
+
+// statusNameKeys are the catalog keys for the normalized task statuses.
+var statusNameKeys = map[string]string{
+	types.StatusPaid:     "statusNamePaid",
+	types.StatusApproved: "statusNameApproved",
+	types.StatusPending:  "statusNamePending",
+	types.StatusRejected: "statusNameRejected",
+}
+
 // HomeHandler serves the main page with the input form.
-func HomeHandler(tmpl *template.Template) http.HandlerFunc {
+func HomeHandler(cfg config.Config, tmpl *template.Template, history *store.Store, profiles parser.Profiles) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/" {
 			http.NotFound(w, r)
 			return
 		}
-		data := types.TemplateData{
-			CurrentYear: time.Now().Year(),
+		data, _ := newTemplateData(w, r, cfg)
+		data.Profiles = profiles.Names()
+		if history != nil {
+			data.HistoryEnabled = true
+			data.HistoryTotal = history.Len()
 		}
 		err := tmpl.Execute(w, data)
 		if err != nil {
@@ -33,15 +57,18 @@ func HomeHandler(tmpl *template.Template) http.HandlerFunc {
 }
 
 // AnalyzeHandler handles the form submission, parses data, analyzes it, and displays results.
-func AnalyzeHandler(tmpl *template.Template) http.HandlerFunc {
+// If the saveHistory box is ticked, the parsed tasks are also upserted into history.
+// CSV columns are read with the mapping profile picked in the form, if any.
+func AnalyzeHandler(cfg config.Config, tmpl *template.Template, history *store.Store, results *store.Results, rates *fx.Table, profiles parser.Profiles) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
+			localeFor(w, r, cfg) // Remember a ?lang= picked on a page without results
 			http.Redirect(w, r, "/", http.StatusSeeOther)
 			return
 		}
 
-		// Set max file size (e.g., 10MB)
-		err := r.ParseMultipartForm(10 << 20)
+		// Limit the upload size (MaxUploadMB, 10MB by default)
+		err := r.ParseMultipartForm(cfg.MaxUploadBytes())
 		if err != nil {
 			log.Printf("Error parsing multipart form: %v", err)
 			http.Error(w, "Error processing form data", http.StatusBadRequest)
@@ -52,95 +79,44 @@ func AnalyzeHandler(tmpl *template.Template) http.HandlerFunc {
 		showDetails := r.FormValue("showDetails") == "on"
 		log.Printf("[DEBUG] Form showDetails=%v", showDetails)
 
-		var tasks []types.Task
-		var inputSource string
-		var rawInputData string // Store the raw input for display
-
-		// Check for file upload first
-		file, handler, err := r.FormFile("csvFile")
-		if err == nil {
-			// File uploaded
-			defer file.Close()
-			log.Printf("Uploaded File: %+v, Size: %+v", handler.Filename, handler.Size)
-
-			// Read the file content to store for display *before* parsing
-			fileBytes, readErr := io.ReadAll(file)
-			if readErr != nil {
-				log.Printf("Error reading uploaded file: %v", readErr)
-				http.Error(w, "Error reading uploaded file", http.StatusInternalServerError)
-				return
-			}
-			rawInputData = string(fileBytes)
-
-			// Parse the CSV data (using a new reader from the read bytes)
-			reader := strings.NewReader(rawInputData)
-			tasks = parser.ParseCSV(reader)
-			log.Printf("[DEBUG] CSV Upload: %d tasks found after initial parse", len(tasks))
-			inputSource = "csv"
-		} else if err != http.ErrMissingFile {
-			// Handle other potential errors from FormFile
-			log.Printf("Error retrieving file from form: %v", err)
+		rawInputData, err := readRawInput(r) // Store the raw input for display
+		if err != nil {
 			http.Error(w, "Error processing file upload", http.StatusInternalServerError)
 			return
-		} else {
-			// No file uploaded, fall back to text input
-			rawInputData = r.FormValue("taskData")
-			specifiedSource := r.FormValue("inputSource") // Check if user specified format
-			log.Printf("[DEBUG] Text input provided. Specified source: %s", specifiedSource)
-
-			if rawInputData != "" {
-				if specifiedSource == "csv" {
-					log.Printf("[DEBUG] Processing text input as CSV")
-					reader := strings.NewReader(rawInputData)
-					tasks = parser.ParseCSV(reader)
-					inputSource = "csv"
-				} else { // Default to text format or if source is 'text'
-					log.Printf("[DEBUG] Processing text input as multi-line text")
-					tasks = parser.ParseText(rawInputData)
-					inputSource = "text"
-				}
-				log.Printf("[DEBUG] Text Input: %d tasks found after initial parse", len(tasks))
-			} else {
-				log.Println("[DEBUG] No file uploaded and text area is empty.")
-				// Optionally, redirect back with an error message?
-			}
 		}
 
-		// *** NEW STEP: Fill missing categories ***
-		if len(tasks) > 0 {
-			log.Printf("[DEBUG] Running FillMissingCategories on %d tasks", len(tasks))
-			tasks = parser.FillMissingCategories(tasks)
-			log.Printf("[DEBUG] FillMissingCategories completed. Task count remains %d", len(tasks))
-		}
+		// Prepare data for the template; amounts are read in the page language's number style
+		data, locale := newTemplateData(w, r, cfg)
 
-		// Prepare data for the template
-		data := types.TemplateData{
-			RawInput:    rawInputData,
-			HasResults:  len(tasks) > 0,
-			InputSource: inputSource,
-			CurrentYear: time.Now().Year(),
-			ShowDetails: showDetails,
-			// Tasks will be populated below if needed
+		profile := profileFor(r, profiles)
+		var result analysis
+		if rawInputData != "" {
+			// Honour an explicit format (e.g. re-posted by the details toggle), otherwise sniff the payload
+			result = runAnalysis(rawInputData, r.FormValue("inputSource"), profile, numberStyle(locale))
+		} else {
+			log.Println("[DEBUG] No file uploaded and text area is empty.")
+			// Optionally, redirect back with an error message?
 		}
 
-		// Analyze the data and format results if we have tasks
-		if len(tasks) > 0 {
-			log.Printf("[DEBUG] Analyzing %d tasks (post-category fill) from source '%s'", len(tasks), inputSource)
-			results := analyzer.AnalyzeData(tasks) // Pass the modified tasks
-
-			// Populate TemplateData with analysis results
-			populateTemplateData(&data, results)
-
-			// Format tasks for display if requested
-			if showDetails {
-				data.Tasks = formatTasksForDisplay(tasks) // Pass the modified tasks
-				log.Printf("[DEBUG] Formatted %d tasks (post-category fill) for details display", len(data.Tasks))
+		data.RawInput = rawInputData
+		data.ShowDetails = showDetails
+		data.Profiles = profiles.Names()
+		if profile != nil {
+			data.Profile = profile.Name
+		}
+		data.SharingEnabled = results != nil
+		populateResults(&data, result, cfg, locale, rates)
+		data.RepostLanguage = data.HasResults
+
+		if history != nil {
+			data.HistoryEnabled = true
+			data.HistoryTotal = history.Len()
+			if r.FormValue("saveHistory") == "on" && len(result.Tasks) > 0 {
+				saveToHistory(&data, history, result.Tasks, locale)
 			}
-		} else {
-			log.Println("[DEBUG] No tasks found to analyze.")
 		}
 
-		log.Printf("[DEBUG] Rendering template: HasResults=%v, ShowDetails=%v, TaskCount=%d", data.HasResults, data.ShowDetails, len(data.Tasks))
+		log.Printf("[DEBUG] Rendering template: HasResults=%v, ShowDetails=%v, TaskCount=%d, Diagnostics=%d", data.HasResults, data.ShowDetails, len(data.Tasks), len(data.Diagnostics))
 		err = tmpl.Execute(w, data)
 		if err != nil {
 			log.Printf("Error executing analyze template: %v", err)
@@ -149,79 +125,268 @@ func AnalyzeHandler(tmpl *template.Template) http.HandlerFunc {
 	}
 }
 
+// readRawInput returns the uploaded file's content if one was sent, otherwise
+// the pasted text from the taskData field. The multipart form must already be parsed.
+func readRawInput(r *http.Request) (string, error) {
+	// Check for file upload first
+	file, handler, err := r.FormFile("csvFile")
+	if err == http.ErrMissingFile {
+		// No file uploaded, fall back to text input
+		return r.FormValue("taskData"), nil
+	}
+	if err != nil {
+		// Handle other potential errors from FormFile
+		log.Printf("Error retrieving file from form: %v", err)
+		return "", err
+	}
+	defer file.Close()
+	log.Printf("Uploaded File: %+v, Size: %+v", handler.Filename, handler.Size)
+
+	// Read the file content to store for display *before* parsing
+	fileBytes, err := io.ReadAll(file)
+	if err != nil {
+		log.Printf("Error reading uploaded file: %v", err)
+		return "", err
+	}
+	input, encoding := parser.DecodeInput(fileBytes)
+	log.Printf("[DEBUG] Uploaded file is %s", encoding)
+	return input, nil
+}
+
 // HealthHandler provides a simple health check endpoint.
 func HealthHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("OK"))
 }
 
+// populateResults fills the results sections of the template from an analysis:
+// format detection, parse warnings, summary breakdowns and, if ShowDetails is
+// set, the task table. Numbers, amounts and labels follow locale; the hour
+// label comes from cfg, and so does the currency symbol unless all tasks name
+// the same currency. With a rate table, the total value is also shown
+// converted into cfg.ReportCurrency.
+func populateResults(data *types.TemplateData, result analysis, cfg config.Config, locale i18n.Locale, rates *fx.Table) {
+	cfg.CurrencySymbol = currencySymbolFor(result.Summary, cfg)
+	data.HasResults = len(result.Tasks) > 0
+	data.Currency = cfg.CurrencySymbol
+	data.InputSource = result.Detection.Format
+	data.Diagnostics = localizeDiagnostics(result.Diagnostics, locale)
+	if result.Detection.Format != "" {
+		data.DetectedFormat = locale.T(formatLabelKeys[result.Detection.Format])
+		data.FormatConfidence = locale.Number(result.Detection.Confidence*100, 0) + "%"
+		data.FormatReason = localizeMessage(locale, result.Detection.ReasonKey, result.Detection.ReasonArgs, result.Detection.Reason)
+	}
+
+	if !data.HasResults {
+		log.Println("[DEBUG] No tasks found to analyze.")
+		return
+	}
+
+	// Populate TemplateData with analysis results
+	populateTemplateData(data, result.Summary, cfg, locale)
+	data.Projects = formatProjectsForDisplay(result.Summary.Projects, cfg, locale)
+	data.PeriodGroups = []types.PeriodGroupDisplay{
+		{Key: "day", Label: locale.T("periodDay"), Rows: formatPeriodsForDisplay(result.Summary.Daily, cfg, locale)},
+		{Key: "week", Label: locale.T("periodWeek"), Rows: formatPeriodsForDisplay(result.Summary.Weekly, cfg, locale)},
+		{Key: "month", Label: locale.T("periodMonth"), Rows: formatPeriodsForDisplay(result.Summary.Monthly, cfg, locale)},
+	}
+	data.UndatedItems = result.Summary.UndatedItems
+	if rates != nil {
+		populateConversion(data, rates.Convert(result.Tasks, cfg.ReportCurrency, cfg.DefaultCurrency), locale)
+	}
+	if result.Summary.MixedCurrencies {
+		data.MixedCurrencies = true
+		data.Currencies = formatCurrenciesForDisplay(result.Summary.Currencies, cfg, locale)
+	}
+
+	// Format tasks for display if requested
+	if data.ShowDetails {
+		data.Tasks = formatTasksForDisplay(analyzer.SortByDate(result.Tasks), cfg, locale) // Oldest first
+		log.Printf("[DEBUG] Formatted %d tasks (post-category fill) for details display", len(data.Tasks))
+	}
+}
+
 // populateTemplateData fills the TemplateData struct with formatted analysis results.
-func populateTemplateData(data *types.TemplateData, results map[string]interface{}) {
-	totalHoursValue := results["TotalHours"].(float64)
-	totalHoursInt := int(totalHoursValue)
-	totalMinutes := int((totalHoursValue - float64(totalHoursInt)) * 60)
+func populateTemplateData(data *types.TemplateData, summary types.Summary, cfg config.Config, locale i18n.Locale) {
+	money := func(m types.Money) string { return locale.Money(m, cfg.CurrencySymbol) }
+
+	data.TotalTasks = summary.TotalTasks
+	data.TotalHours = locale.Hours(summary.TotalTime, cfg.HourLabel)
+	data.TotalValue = money(summary.TotalValue)
+	data.TasksValue = money(summary.Tasks.Value)
+	data.ExceededTimeValue = money(summary.ExceededTime.Value)
+	data.OtherValue = money(summary.Other.Value)
+	data.AverageHourlyRate = locale.Rate(summary.AverageHourlyRate, cfg.CurrencySymbol)
+
+	data.ConfirmedValue = money(summary.Status.Confirmed)
+	data.PaidValue = money(summary.Status.Paid.Value)
+	data.ApprovedValue = money(summary.Status.Approved.Value)
+	data.PendingValue = money(summary.Status.Pending.Value)
+	data.RejectedValue = money(summary.Status.Rejected.Value)
+	data.ExpectedValue = money(summary.Status.Expected)
+	data.PendingCount = summary.Status.Pending.Count
+	data.RejectedCount = summary.Status.Rejected.Count
+
+	data.TaskHours = locale.Hours(summary.Tasks.Time, cfg.HourLabel)
+	data.ExceededTimeHours = locale.Hours(summary.ExceededTime.Time, cfg.HourLabel)
+	data.OtherHours = locale.Hours(summary.Other.Time, cfg.HourLabel)
+
+	data.AvgTimePerTask = formatDuration(summary.AvgTimePerTask, locale)
+	data.AvgValuePerTask = money(summary.AvgValuePerTask)
 
-	taskHoursValue := results["TaskHours"].(float64)
-	taskHoursInt := int(taskHoursValue)
-	taskMinutes := int((taskHoursValue - float64(taskHoursInt)) * 60)
+	// Calculate hour percentages for progress bars
+	if summary.TotalTime > 0 {
+		total := float64(summary.TotalTime)
+		taskPercentage := float64(summary.Tasks.Time) / total * 100
+		exceededPercentage := float64(summary.ExceededTime.Time) / total * 100
+		otherPercentage := float64(summary.Other.Time) / total * 100
+		data.RawHourPercentages = []float64{taskPercentage, exceededPercentage, otherPercentage}
+	} else {
+		data.RawHourPercentages = []float64{0, 0, 0}
+	}
+	data.RawValues = []float64{summary.Tasks.Value.Float(), summary.ExceededTime.Value.Float(), summary.Other.Value.Float()}
+}
 
-	exceededTimeHoursValue := results["ExceededTimeHours"].(float64)
-	exceededTimeHoursInt := int(exceededTimeHoursValue)
-	exceededTimeMinutes := int((exceededTimeHoursValue - float64(exceededTimeHoursInt)) * 60)
+// localizeDiagnostics returns diags with their messages in the locale's
+// language.
+func localizeDiagnostics(diags []types.Diagnostic, locale i18n.Locale) []types.Diagnostic {
+	localized := make([]types.Diagnostic, len(diags))
+	for i, d := range diags {
+		d.Message = localizeMessage(locale, d.Key, d.Args, d.Message)
+		localized[i] = d
+	}
+	return localized
+}
 
-	otherHoursValue := results["OtherHours"].(float64)
-	otherHoursInt := int(otherHoursValue)
-	otherMinutes := int((otherHoursValue - float64(otherHoursInt)) * 60)
+// localizeMessage formats the catalog message key with args in the locale's
+// language, or returns english if there is no key, as for results saved
+// before messages were in the catalog.
+func localizeMessage(locale i18n.Locale, key string, args []string, english string) string {
+	if key == "" {
+		return english
+	}
+	anyArgs := make([]any, len(args))
+	for i, arg := range args {
+		anyArgs[i] = arg
+	}
+	return locale.Tf(key, anyArgs...)
+}
 
-	avgTimePerTaskValue := results["AvgTimePerTask"].(float64) // This is in minutes
-	avgTimeMinutes := int(avgTimePerTaskValue)
-	avgTimeSeconds := int((avgTimePerTaskValue - float64(avgTimeMinutes)) * 60)
+// formatDuration renders a duration as "Xm Ys", rounded to the nearest second.
+func formatDuration(d time.Duration, locale i18n.Locale) string {
+	seconds := int64(d.Round(time.Second) / time.Second)
+	return locale.Tf("minSec", seconds/60, seconds%60)
+}
 
-	avgValuePerTaskValue := results["AvgValuePerTask"].(float64)
+// formatProjectsForDisplay converts per-project summaries into rows for the project table.
+func formatProjectsForDisplay(projects []types.ProjectSummary, cfg config.Config, locale i18n.Locale) []types.ProjectDisplay {
+	var projectDisplays []types.ProjectDisplay
+	for _, project := range projects {
+		name := project.Name
+		if name == "" {
+			name = locale.T("noProject")
+		}
+		projectDisplays = append(projectDisplays, types.ProjectDisplay{
+			Name:              name,
+			TaskCount:         project.TaskCount,
+			TotalHours:        locale.Number(project.TotalTime.Hours(), 2),
+			TaskHours:         locale.Number(project.TaskTime.Hours(), 2),
+			ExceededTimeHours: locale.Number(project.ExceededTime.Hours(), 2),
+			Value:             locale.Money(project.Value, cfg.CurrencySymbol),
+			EffectiveRate:     locale.Rate(project.EffectiveRate, cfg.CurrencySymbol),
+			AvgTimePerTask:    formatDuration(project.AvgTimePerTask, locale),
+			SortTotalHours:    project.TotalTime.Hours(),
+			SortTaskHours:     project.TaskTime.Hours(),
+			SortExceededHours: project.ExceededTime.Hours(),
+			SortValue:         project.Value.Float(),
+			SortEffectiveRate: project.EffectiveRate.Float(),
+			SortAvgTime:       project.AvgTimePerTask.Minutes(),
+		})
+	}
+	return projectDisplays
+}
 
-	data.TotalTasks = results["TotalTasks"].(int)
-	data.TotalHours = fmt.Sprintf("%.2f horas (%dh %dmin)", totalHoursValue, totalHoursInt, totalMinutes)
-	data.TotalValue = fmt.Sprintf("%.2f", results["TotalValue"].(float64))
-	data.TasksValue = fmt.Sprintf("%.2f", results["TasksValue"].(float64))
-	data.ExceededTimeValue = fmt.Sprintf("%.2f", results["ExceededTimeValue"].(float64))
-	data.OtherValue = fmt.Sprintf("%.2f", results["OtherValue"].(float64))
-	data.AverageHourlyRate = fmt.Sprintf("%.2f", results["AverageHourlyRate"].(float64))
+// populateConversion fills the converted total shown next to TotalValue.
+func populateConversion(data *types.TemplateData, conversion types.Conversion, locale i18n.Locale) {
+	data.ConvertedCurrency = conversion.Currency
+	data.ConvertedValue = locale.Money(conversion.Value, parser.CurrencySymbol(conversion.Currency))
+	data.UnconvertedCount = conversion.Unconverted
+	data.MissingRates = locale.MissingRates(conversion.MissingRates)
+	log.Printf("[DEBUG] Converted %d tasks into %s (%d without a rate)", conversion.Converted, conversion.Currency, conversion.Unconverted)
+}
 
-	data.TaskHours = fmt.Sprintf("%.2f horas (%dh %dmin)", taskHoursValue, taskHoursInt, taskMinutes)
-	data.ExceededTimeHours = fmt.Sprintf("%.2f horas (%dh %dmin)", exceededTimeHoursValue, exceededTimeHoursInt, exceededTimeMinutes)
-	data.OtherHours = fmt.Sprintf("%.2f horas (%dh %dmin)", otherHoursValue, otherHoursInt, otherMinutes)
+// currencySymbolFor returns the symbol for the currency every task in the
+// summary was written in, or cfg.CurrencySymbol if none was named or there is
+// more than one.
+func currencySymbolFor(summary types.Summary, cfg config.Config) string {
+	if code := export.SummaryCurrency(summary); code != "" {
+		return parser.CurrencySymbol(code)
+	}
+	return cfg.CurrencySymbol
+}
 
-	data.AvgTimePerTask = fmt.Sprintf("%dm %ds", avgTimeMinutes, avgTimeSeconds)
-	data.AvgValuePerTask = fmt.Sprintf("$%.2f", avgValuePerTaskValue)
+// formatCurrenciesForDisplay converts per-currency totals into table rows, each
+// amount with its own currency's symbol.
+func formatCurrenciesForDisplay(currencies []types.CurrencySummary, cfg config.Config, locale i18n.Locale) []types.CurrencyDisplay {
+	var currencyDisplays []types.CurrencyDisplay
+	for _, currency := range currencies {
+		code, symbol := currency.Currency, cfg.CurrencySymbol
+		if code == "" {
+			code = locale.T("noCurrency")
+		} else {
+			symbol = parser.CurrencySymbol(code)
+		}
+		currencyDisplays = append(currencyDisplays, types.CurrencyDisplay{
+			Currency:  code,
+			ItemCount: currency.ItemCount,
+			Hours:     locale.Number(currency.Time.Hours(), 2),
+			Value:     locale.Money(currency.Value, symbol),
+		})
+	}
+	return currencyDisplays
+}
 
-	// Calculate hour percentages for progress bars
-	if totalHoursValue > 0 {
-		taskPercentage := (taskHoursValue / totalHoursValue) * 100
-		exceededPercentage := (exceededTimeHoursValue / totalHoursValue) * 100
-		otherPercentage := (otherHoursValue / totalHoursValue) * 100
-		data.RawHourPercentages = []float64{taskPercentage, exceededPercentage, otherPercentage}
-	} else {
-		data.RawHourPercentages = []float64{0, 0, 0}
+// formatPeriodsForDisplay converts daily/weekly/monthly summaries into table rows.
+func formatPeriodsForDisplay(periods []types.PeriodSummary, cfg config.Config, locale i18n.Locale) []types.PeriodDisplay {
+	var periodDisplays []types.PeriodDisplay
+	for _, period := range periods {
+		periodDisplays = append(periodDisplays, types.PeriodDisplay{
+			Period:        period.Period,
+			ItemCount:     period.ItemCount,
+			Hours:         locale.Number(period.Time.Hours(), 2),
+			Value:         locale.Money(period.Value, cfg.CurrencySymbol),
+			EffectiveRate: locale.Rate(period.EffectiveRate, cfg.CurrencySymbol),
+		})
 	}
+	return periodDisplays
 }
 
 // formatTasksForDisplay converts raw Task structs into TaskDisplay structs for the HTML table.
-func formatTasksForDisplay(tasks []types.Task) []types.TaskDisplay {
+func formatTasksForDisplay(tasks []types.Task, cfg config.Config, locale i18n.Locale) []types.TaskDisplay {
 	var taskDisplays []types.TaskDisplay
 	for _, task := range tasks {
 		rateDisplay := "-"
 		durationDisplay := task.Duration
 		durationMinsDisplay := "-"
 
-		if task.DurationMins > 0 {
-			durationMinsDisplay = fmt.Sprintf("%.2f mins", task.DurationMins)
+		if task.Elapsed > 0 {
+			durationMinsDisplay = locale.Tf("minutesValue", locale.Number(task.Elapsed.Minutes(), 2))
 		}
 		if task.Duration == "" { // Ensure empty duration shows as '-'
 			durationDisplay = "-"
 		}
 
+		symbol := cfg.CurrencySymbol
+		if task.Currency != "" {
+			symbol = parser.CurrencySymbol(task.Currency)
+		}
 		if task.Rate > 0 {
-			rateDisplay = fmt.Sprintf("$%.2f/hr", task.Rate)
+			rateDisplay = locale.Rate(task.Rate, symbol)
+		}
+
+		status := task.Status
+		if key, ok := statusNameKeys[task.Status]; ok {
+			status = locale.T(key)
 		}
 
 		taskDisplays = append(taskDisplays, types.TaskDisplay{
@@ -230,9 +395,9 @@ func formatTasksForDisplay(tasks []types.Task) []types.TaskDisplay {
 			Category:     task.Category,
 			Duration:     durationDisplay,
 			Rate:         rateDisplay,
-			Value:        fmt.Sprintf("$%.2f", task.Value),
+			Value:        locale.Money(task.Value, symbol),
 			Type:         task.Type,
-			Status:       task.Status,
+			Status:       status,
 			DurationMins: durationMinsDisplay,
 		})
 	}
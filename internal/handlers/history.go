@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/analyzer"
+	"github.com/erickgnclvs/go-task-viewer/internal/config"
+	"github.com/erickgnclvs/go-task-viewer/internal/fx"
+	"github.com/erickgnclvs/go-task-viewer/internal/i18n"
+	"github.com/erickgnclvs/go-task-viewer/internal/parser"
+	"github.com/erickgnclvs/go-task-viewer/internal/store"
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// HistoryHandler renders the results page over the stored task history,
+// optionally limited to the ?from= and ?to= work dates (inclusive).
+func HistoryHandler(cfg config.Config, tmpl *template.Template, history *store.Store, rates *fx.Table, profiles parser.Profiles) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if history == nil {
+			http.Error(w, "Task history is disabled", http.StatusNotFound)
+			return
+		}
+
+		fromStr, toStr := r.FormValue("from"), r.FormValue("to")
+		from, to, err := parseDateRange(fromStr, toStr)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		data, locale := newTemplateData(w, r, cfg)
+		data.ShowDetails = r.FormValue("showDetails") == "on"
+		data.HistoryEnabled = true
+		data.HistoryTotal = history.Len()
+		data.HistoryView = true
+		data.HistoryFrom = fromStr
+		data.HistoryTo = toStr
+		data.Profiles = profiles.Names()
+		populateResults(&data, historyAnalysis(history, from, to), cfg, locale, rates)
+
+		log.Printf("[DEBUG] Rendering history: from=%q to=%q HasResults=%v", fromStr, toStr, data.HasResults)
+		if err := tmpl.Execute(w, data); err != nil {
+			log.Printf("Error executing history template: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+	}
+}
+
+// historyAnalysis analyzes the stored tasks that fall within [from, to], oldest first.
+func historyAnalysis(history *store.Store, from, to time.Time) analysis {
+	result := analysis{Tasks: analyzer.SortByDate(analyzer.FilterByDate(history.Tasks(), from, to))}
+	if len(result.Tasks) > 0 {
+		result.Summary = analyzer.AnalyzeData(result.Tasks)
+	}
+	return result
+}
+
+// parseDateRange reads the optional from/to bounds of a history query.
+func parseDateRange(fromStr, toStr string) (from, to time.Time, err error) {
+	if fromStr != "" {
+		if from, err = parser.ParseDate(fromStr); err != nil {
+			return from, to, fmt.Errorf("invalid 'from' date: %v", err)
+		}
+	}
+	if toStr != "" {
+		if to, err = parser.ParseDate(toStr); err != nil {
+			return from, to, fmt.Errorf("invalid 'to' date: %v", err)
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return from, to, fmt.Errorf("'to' date is before 'from' date")
+	}
+	return from, to, nil
+}
+
+// saveToHistory upserts the analyzed tasks and records the outcome on the page.
+func saveToHistory(data *types.TemplateData, history *store.Store, tasks []types.Task, locale i18n.Locale) {
+	result, err := history.Upsert(tasks)
+	if err != nil {
+		log.Printf("Error saving tasks to history: %v", err)
+		data.HistoryImport = locale.T("historySaveFailed")
+		return
+	}
+	data.HistoryTotal = result.Total
+	data.HistoryImport = locale.Tf("historyUpdated", result.Added, result.Updated, result.Unchanged, result.Total)
+}
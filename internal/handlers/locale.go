@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/config"
+	"github.com/erickgnclvs/go-task-viewer/internal/i18n"
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// localeCookie remembers the language picked with ?lang= across pages.
+const localeCookie = "lang"
+
+// localeFor picks the interface language: ?lang= (which is then remembered in
+// a cookie), the cookie, the Accept-Language header, and finally cfg.Locale.
+func localeFor(w http.ResponseWriter, r *http.Request, cfg config.Config) i18n.Locale {
+	if locale, ok := i18n.Lookup(r.URL.Query().Get("lang")); ok {
+		http.SetCookie(w, &http.Cookie{
+			Name:     localeCookie,
+			Value:    locale.Tag,
+			Path:     "/",
+			MaxAge:   int((365 * 24 * time.Hour).Seconds()),
+			SameSite: http.SameSiteLaxMode,
+		})
+		return locale
+	}
+	if cookie, err := r.Cookie(localeCookie); err == nil {
+		if locale, ok := i18n.Lookup(cookie.Value); ok {
+			return locale
+		}
+	}
+	if locale, ok := i18n.Match(r.Header.Get("Accept-Language")); ok {
+		return locale
+	}
+	locale, _ := i18n.Lookup(cfg.Locale) // Validated at startup
+	return locale
+}
+
+// newTemplateData returns the page data shared by every view: the year, the
+// selected language with its messages, and the language switcher.
+func newTemplateData(w http.ResponseWriter, r *http.Request, cfg config.Config) (types.TemplateData, i18n.Locale) {
+	locale := localeFor(w, r, cfg)
+	data := types.TemplateData{
+		CurrentYear: time.Now().Year(),
+		Lang:        locale.Tag,
+		T:           locale.Messages(),
+	}
+	for _, l := range i18n.Locales {
+		data.Languages = append(data.Languages, types.LanguageOption{Tag: l.Tag, Name: l.Name, URL: languageURL(r, l.Tag), Active: l.Tag == locale.Tag})
+	}
+	return data, locale
+}
+
+// languageURL returns the requested page with ?lang=tag, keeping the rest of
+// its query (e.g. the /history date range).
+func languageURL(r *http.Request, tag string) string {
+	query := r.URL.Query()
+	query.Set("lang", tag)
+	return r.URL.Path + "?" + query.Encode()
+}
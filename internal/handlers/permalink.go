@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/analyzer"
+	"github.com/erickgnclvs/go-task-viewer/internal/config"
+	"github.com/erickgnclvs/go-task-viewer/internal/fx"
+	"github.com/erickgnclvs/go-task-viewer/internal/parser"
+	"github.com/erickgnclvs/go-task-viewer/internal/store"
+)
+
+// ShareHandler analyzes the posted input, saves the result and redirects to its permalink.
+func ShareHandler(cfg config.Config, results *store.Results, profiles parser.Profiles) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Redirect(w, r, "/", http.StatusSeeOther)
+			return
+		}
+		if results == nil {
+			http.Error(w, "Saved results are disabled", http.StatusNotFound)
+			return
+		}
+
+		// Limit the upload size (MaxUploadMB, 10MB by default)
+		if err := r.ParseMultipartForm(cfg.MaxUploadBytes()); err != nil {
+			log.Printf("Error parsing multipart form: %v", err)
+			http.Error(w, "Error processing form data", http.StatusBadRequest)
+			return
+		}
+
+		rawInputData, err := readRawInput(r)
+		if err != nil {
+			http.Error(w, "Error processing file upload", http.StatusInternalServerError)
+			return
+		}
+
+		result := runAnalysis(rawInputData, r.FormValue("inputSource"), profileFor(r, profiles), numberStyle(localeFor(w, r, cfg)))
+		if len(result.Tasks) == 0 {
+			http.Error(w, "No tasks to save", http.StatusUnprocessableEntity)
+			return
+		}
+
+		id, err := results.Save(store.SavedResult{
+			Format:      result.Detection.Format,
+			Confidence:  result.Detection.Confidence,
+			Reason:      result.Detection.Reason,
+			ReasonKey:   result.Detection.ReasonKey,
+			ReasonArgs:  result.Detection.ReasonArgs,
+			Tasks:       result.Tasks,
+			Diagnostics: result.Diagnostics,
+		})
+		if err != nil {
+			log.Printf("Error saving result: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		location := "/r/" + id
+		if r.FormValue("showDetails") == "on" {
+			location += "?showDetails=on"
+		}
+		log.Printf("Saved result %s with %d tasks", id, len(result.Tasks))
+		http.Redirect(w, r, location, http.StatusSeeOther)
+	}
+}
+
+// ResultHandler re-renders a saved result at /r/{id}; ?showDetails=on shows the task table.
+func ResultHandler(cfg config.Config, tmpl *template.Template, history *store.Store, results *store.Results, rates *fx.Table, profiles parser.Profiles) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/r/")
+		if results == nil || id == "" || strings.Contains(id, "/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		result, err := loadAnalysis(results, id)
+		if errors.Is(err, store.ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			log.Printf("Error loading result %s: %v", id, err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+
+		data, locale := newTemplateData(w, r, cfg)
+		data.ShowDetails = r.FormValue("showDetails") == "on"
+		data.ResultID = id
+		data.PermalinkURL = absoluteURL(r, "/r/"+id)
+		data.Profiles = profiles.Names()
+		if history != nil {
+			data.HistoryEnabled = true
+			data.HistoryTotal = history.Len()
+		}
+		populateResults(&data, result, cfg, locale, rates)
+
+		if err := tmpl.Execute(w, data); err != nil {
+			log.Printf("Error executing result template: %v", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		}
+	}
+}
+
+// loadAnalysis loads a saved result and recomputes its summary.
+func loadAnalysis(results *store.Results, id string) (analysis, error) {
+	saved, err := results.Load(id)
+	if err != nil {
+		return analysis{}, err
+	}
+	result := analysis{
+		Detection: parser.Detection{
+			Format:     saved.Format,
+			Confidence: saved.Confidence,
+			Reason:     saved.Reason,
+			ReasonKey:  saved.ReasonKey,
+			ReasonArgs: saved.ReasonArgs,
+		},
+		Tasks:       saved.Tasks,
+		Diagnostics: saved.Diagnostics,
+	}
+	if len(result.Tasks) > 0 {
+		result.Summary = analyzer.AnalyzeData(result.Tasks)
+	}
+	return result, nil
+}
+
+// absoluteURL builds a full URL for path on the host the request came in on,
+// honouring X-Forwarded-Proto from a TLS-terminating proxy such as Railway's.
+func absoluteURL(r *http.Request, path string) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + path
+}
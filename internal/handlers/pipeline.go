@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/analyzer"
+	"github.com/erickgnclvs/go-task-viewer/internal/i18n"
+	"github.com/erickgnclvs/go-task-viewer/internal/parser"
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// analysis is the outcome of running one input through the parse and analyze pipeline.
+type analysis struct {
+	Detection   parser.Detection
+	Tasks       []types.Task // After FillMissingCategories
+	Diagnostics []types.Diagnostic
+	Summary     types.Summary
+}
+
+// runAnalysis parses raw with the given format, or the detected one if format
+// is not a known parser format, fills missing categories and analyzes the tasks.
+// A given format that agrees with detection keeps the detection's confidence
+// and reason, so a re-posted results form shows them unchanged.
+// Delimited input is read with the column and pay type names of profile, which
+// may be nil for the built-in names only. Amounts that could be read either
+// way, such as "1.234" without a currency, are read in style.
+func runAnalysis(raw, format string, profile *parser.Profile, style parser.NumberStyle) analysis {
+	var result analysis
+	result.Detection = profile.DetectFormat(raw)
+	if parser.IsFormat(format) && format != result.Detection.Format {
+		result.Detection = parser.Specified(format)
+	}
+	log.Printf("[DEBUG] Input format: %s (confidence %.2f, %s)", result.Detection.Format, result.Detection.Confidence, result.Detection.Reason)
+	if profile != nil {
+		log.Printf("[DEBUG] Using mapping profile '%s'", profile.Name)
+	}
+
+	result.Tasks, result.Diagnostics = profile.Parse(result.Detection.Format, raw, style)
+	log.Printf("[DEBUG] %d tasks found after initial parse", len(result.Tasks))
+
+	if len(result.Tasks) > 0 {
+		log.Printf("[DEBUG] Running FillMissingCategories on %d tasks", len(result.Tasks))
+		result.Tasks = parser.FillMissingCategories(result.Tasks)
+
+		log.Printf("[DEBUG] Analyzing %d tasks (post-category fill) from source '%s'", len(result.Tasks), result.Detection.Format)
+		result.Summary = analyzer.AnalyzeData(result.Tasks)
+	}
+	return result
+}
+
+// numberStyle returns the number style of the locale's separators, so that a
+// Brazilian user's "1.234" is read as one thousand two hundred thirty-four.
+func numberStyle(locale i18n.Locale) parser.NumberStyle {
+	if locale.DecimalComma() {
+		return parser.StyleComma
+	}
+	return parser.StyleDot
+}
+
+// profileFor returns the mapping profile named in the request's "profile"
+// field, or nil if none was picked. A name that is not among profiles, e.g.
+// from a form rendered before a restart, also gives nil.
+func profileFor(r *http.Request, profiles parser.Profiles) *parser.Profile {
+	name := r.FormValue("profile")
+	if name == "" {
+		return nil
+	}
+	profile := profiles.Lookup(name)
+	if profile == nil {
+		log.Printf("Warning: unknown mapping profile '%s'; using the built-in column names", name)
+	}
+	return profile
+}
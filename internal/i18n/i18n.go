@@ -0,0 +1,198 @@
+// Package i18n holds the message catalog for the web interface and formats
+// numbers, amounts and durations for each supported locale.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// Locale is one supported interface language with its number conventions.
+type Locale struct {
+	Tag         string // BCP 47 tag, e.g. "pt-BR"
+	Name        string // Name of the language in itself, for the language switcher
+	decimal     string
+	group       string
+	symbolSpace bool // Put a space between the currency symbol and the amount
+	messages    map[string]string
+}
+
+// Locales lists the supported locales. The first one is the fallback for
+// messages missing from another catalog.
+var Locales = []Locale{
+	{Tag: "en", Name: "English", decimal: ".", group: ",", messages: messagesEN},
+	{Tag: "pt-BR", Name: "Português", decimal: ",", group: ".", symbolSpace: true, messages: messagesPT},
+}
+
+// English is the locale of the logs, and of the messages the API and the
+// command line print.
+var English = Locales[0]
+
+// Lookup returns the locale for tag, ignoring case.
+func Lookup(tag string) (Locale, bool) {
+	for _, locale := range Locales {
+		if strings.EqualFold(locale.Tag, tag) {
+			return locale, true
+		}
+	}
+	return Locale{}, false
+}
+
+// Match picks the best supported locale for an Accept-Language header,
+// matching exact tags first and then the base language ("pt-PT" gets "pt-BR").
+// It returns false if nothing in the header is supported.
+func Match(acceptLanguage string) (Locale, bool) {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > 0 {
+			tags = append(tags, weighted{tag, q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	for _, t := range tags {
+		if locale, ok := Lookup(t.tag); ok {
+			return locale, true
+		}
+		base, _, _ := strings.Cut(t.tag, "-")
+		for _, locale := range Locales {
+			localeBase, _, _ := strings.Cut(locale.Tag, "-")
+			if strings.EqualFold(base, localeBase) {
+				return locale, true
+			}
+		}
+	}
+	return Locale{}, false
+}
+
+// T returns the message for key, falling back to English and then to the key itself.
+func (l Locale) T(key string) string {
+	if msg, ok := l.messages[key]; ok {
+		return msg
+	}
+	if msg, ok := Locales[0].messages[key]; ok {
+		return msg
+	}
+	return key
+}
+
+// Tf formats the message for key with args, like fmt.Sprintf.
+func (l Locale) Tf(key string, args ...any) string {
+	return fmt.Sprintf(l.T(key), args...)
+}
+
+// Messages returns the full catalog for the locale, with English filling any
+// gaps, for use as {{ .T.key }} in templates.
+func (l Locale) Messages() map[string]string {
+	messages := make(map[string]string, len(Locales[0].messages))
+	for key, msg := range Locales[0].messages {
+		messages[key] = msg
+	}
+	for key, msg := range l.messages {
+		messages[key] = msg
+	}
+	return messages
+}
+
+// Number formats v with the given number of decimals and the locale's
+// separators, e.g. 1234.5 as "1,234.50" or "1.234,50".
+func (l Locale) Number(v float64, decimals int) string {
+	return l.localize(strconv.FormatFloat(v, 'f', decimals, 64))
+}
+
+// DecimalComma reports whether the locale writes decimals after a comma, as in
+// "1.234,50".
+func (l Locale) DecimalComma() bool {
+	return l.decimal == ","
+}
+
+// Money formats an exact amount with the currency symbol in the locale's
+// style, e.g. "$1,234.50" or "R$ 1.234,50".
+func (l Locale) Money(m types.Money, symbol string) string {
+	amount := m.String()
+	sign := ""
+	if strings.HasPrefix(amount, "-") {
+		sign, amount = "-", amount[1:]
+	}
+	if l.symbolSpace && symbol != "" {
+		symbol += " "
+	}
+	return sign + symbol + l.localize(amount)
+}
+
+// Rate formats an hourly rate, e.g. "$20.00/hr".
+func (l Locale) Rate(m types.Money, symbol string) string {
+	return l.Money(m, symbol) + l.T("perHourShort")
+}
+
+// Hours formats a duration in hours with its hours-and-minutes breakdown, e.g.
+// "7.50 hours (7h 30m)". The breakdown is rounded to the nearest minute, so
+// 59m 59s reads "1h 0m" in agreement with "1.00". An empty label uses the
+// locale's word for hours.
+func (l Locale) Hours(d time.Duration, label string) string {
+	if label == "" {
+		label = l.T("hoursUnit")
+	}
+	minutes := int64(d.Round(time.Minute) / time.Minute)
+	return l.Tf("hoursDuration", l.Number(d.Hours(), 2), label, minutes/60, minutes%60)
+}
+
+// MissingRates lists the exchange rates a conversion needed but did not find,
+// e.g. "USD 2025-03-30, EUR undated".
+func (l Locale) MissingRates(rates []types.MissingRate) string {
+	names := make([]string, len(rates))
+	for i, r := range rates {
+		switch {
+		case r.Currency == "":
+			names[i] = l.T("rateNoCurrency")
+		case r.Date.IsZero():
+			names[i] = l.Tf("rateUndated", r.Currency)
+		default:
+			names[i] = r.Currency + " " + r.Date.Format("2006-01-02")
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// localize swaps the separators of a plain decimal string ("-1234.50") for
+// the locale's and groups the integer digits in threes.
+func (l Locale) localize(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, hasFrac := strings.Cut(s, ".")
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(l.group)
+		}
+		b.WriteRune(digit)
+	}
+	if hasFrac {
+		b.WriteString(l.decimal)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
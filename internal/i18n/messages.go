@@ -0,0 +1,351 @@
+package i18n
+
+// messagesEN is the English catalog, also the fallback for missing messages.
+var messagesEN = map[string]string{
+	// Page and input form
+	"pageTitle":        "Task Analyzer",
+	"language":         "Language",
+	"howToUse":         "How to Use",
+	"howToUseAlt":      "Usage demo",
+	"pasteText":        "Paste Text",
+	"uploadFile":       "Upload CSV",
+	"pastePlaceholder": "Paste the report text here...",
+	"dropFile":         "Drag and drop the CSV file here",
+	"or":               "or",
+	"chooseFile":       "Choose File",
+	"saveHistory":      "Save to history",
+	"profileLabel":     "Column mapping:",
+	"profileNone":      "Built-in columns",
+	"analyze":          "Analyze",
+	"viewOnGitHub":     "View on GitHub",
+
+	// History
+	"historyLabel":      "History (%d tasks):",
+	"historyFrom":       "From",
+	"historyTo":         "To",
+	"viewHistory":       "View history",
+	"historyEmpty":      "No dated tasks in the history for this period.",
+	"historySaveFailed": "Could not save to the history.",
+	"historyUpdated":    "History updated: %d new, %d updated, %d unchanged (%d in total).",
+
+	// Permalinks and format detection
+	"permalink":      "Link to this result:",
+	"detectedFormat": "Detected format:",
+	"confidence":     "(%s confidence)",
+	"formatCSV":      "CSV",
+	"formatTSV":      "TSV (tab-separated)",
+	"formatSemi":     "CSV (semicolon)",
+	"formatText":     "Text (8-line blocks)",
+	"formatLabeled":  "Text (Key: value)",
+	"formatJSON":     "JSON",
+
+	// Format detection reasons. The parser fills in every argument as a string.
+	"reasonSpecified": "format given explicitly",
+	"reasonEmpty":     "empty input",
+	"reasonFallback":  "no known structure found; falling back to text blocks",
+	"reasonJSON":      "valid JSON document",
+	"reasonNDJSON":    "one JSON object per line (NDJSON)",
+	"reasonBadJSON":   "starts like JSON but only %s of %s lines are valid JSON",
+	"reasonHeader":    "header has %s known columns separated by %s",
+	"reasonLabeled":   "%s of %s lines are 'Key: value' pairs",
+	"reasonText":      "%s of %s lines belong to 8-line task blocks",
+	"reasonShape":     "%s consistent %s-separated columns but no known header",
+
+	// Parse warnings
+	"diagnosticsTitle": "⚠️ Parse Warnings (%d)",
+	"diagnosticsNote":  "Lines dropped or with fields zeroed while reading. Check them before trusting the totals.",
+	"colLine":          "Line",
+	"colSeverity":      "Severity",
+	"colField":         "Field",
+	"colMessage":       "Message",
+	"colContent":       "Content",
+
+	// Parse warning messages. The parser fills in every argument as a string.
+	"diagRead":          "could not read input: %s",
+	"diagHeader":        "could not read CSV header: %s",
+	"diagNoValueCol":    "no payout/value column found in header; all values will be 0",
+	"diagRowDropped":    "row dropped: %s",
+	"diagJSON":          "could not read JSON input: %s",
+	"diagNotObject":     "entry skipped: not a JSON object: %s",
+	"diagNotDocument":   "input skipped: JSON is not an object or array",
+	"diagNotScalar":     "'%s' is not a string or number; ignored",
+	"diagIncomplete":    "line skipped: incomplete task block",
+	"diagIncompletes":   "lines %s-%s skipped: incomplete task block",
+	"diagNoBlock":       "line skipped: not part of a task",
+	"diagNoBlocks":      "lines %s-%s skipped: not part of a task",
+	"diagNoLabels":      "line skipped: labeled block has no ID, Duration or Value",
+	"diagNoValueLabel":  "no Value label in block; set to 0",
+	"diagNoValue":       "no value found; set to 0",
+	"diagAmount":        "could not parse %s '%s'; set to 0",
+	"diagAmbiguous":     "%s '%s' could be read either way; read as %s",
+	"diagCurrency":      "unknown currency '%s'; ignored",
+	"diagValueCurrency": "value is written in %s but the task's other amounts are in %s; task counted as %s",
+	"diagFieldCurrency": "%s is written in %s but the task's other amounts are in %s; task counted as %s",
+	"diagDuration":      "%s; counted as 0 minutes",
+	"diagMoneyDuration": "duration '%s' looks like a money value; ignored",
+	"diagDate":          "could not read date '%s'; task left out of date grouping and sorting",
+	"diagTaskType":      "unknown task type '%s'; counted as Other",
+	"diagPayType":       "unknown pay type '%s'; counted as Other",
+	"diagStatus":        "unknown status '%s'; counted as pending",
+	"logParseText":      "Parsing %d characters of text",
+	"logSplitLines":     "Split into %d lines",
+
+	// Currencies
+	"currencyTitle":    "⚠️ Multiple Currencies",
+	"currencyWarning":  "The tasks are in more than one currency. The totals and averages below add them together unconverted; use the amounts per currency here instead.",
+	"colCurrency":      "Currency",
+	"noCurrency":       "(not stated)",
+	"convertedValue":   "≈ %s in %s",
+	"unconvertedCount": "%d task(s) without an exchange rate left out",
+	"missingRates":     "No exchange rate for: %s",
+	"rateUndated":      "%s undated",
+	"rateNoCurrency":   "unknown currency",
+
+	// Overview
+	"overview":          "Overview",
+	"overviewHistory":   "History Overview",
+	"overviewSince":     " from %s",
+	"overviewUntil":     " to %s",
+	"totalTasks":        "Total Tasks",
+	"hoursWorked":       "Hours Worked",
+	"totalValue":        "Total Value",
+	"averagesTitle":     "Averages per Task",
+	"avgTime":           "Average time per task",
+	"avgValue":          "Average value per task",
+	"avgRate":           "Average value per hour",
+	"valueDistribution": "Value Distribution",
+	"typeTasks":         "Tasks (Task)",
+	"typeExceeded":      "Exceeded Time",
+	"typeOther":         "Other",
+
+	// Earnings by status
+	"statusConfirmed":    "Confirmed (paid %s + approved %s)",
+	"statusPending":      "Pending (%d items)",
+	"statusRejected":     "Rejected (%d items)",
+	"expectedHint":       "Expected (confirmed + pending): %s. The total value above includes rejected items.",
+	"statusNamePaid":     "paid",
+	"statusNameApproved": "approved",
+	"statusNamePending":  "pending",
+	"statusNameRejected": "rejected",
+
+	// Hours
+	"hoursByType":     "Hours by Type",
+	"hoursChartTitle": "Hours Distribution by Type",
+	"hoursTasks":      "Hours on Tasks (Task)",
+	"hoursExceeded":   "Hours on Exceeded Time",
+	"hoursOther":      "Hours on Other",
+	"hoursUnit":       "hours",
+	"hoursDuration":   "%s %s (%dh %dm)",
+	"perHourShort":    "/hr",
+	"minutesValue":    "%s mins",
+	"minSec":          "%dm %ds",
+
+	// Projects
+	"projectsTitle":  "Performance by Project",
+	"sortHint":       "Click a column header to sort.",
+	"noProject":      "(no project)",
+	"colProject":     "Project",
+	"colTasks":       "Tasks",
+	"colTotalHours":  "Total Hours",
+	"colTaskHours":   "Task Hours",
+	"colExceededHrs": "Exceeded Hours",
+	"colValue":       "Value",
+	"colRate":        "Value/Hour",
+	"colAvgTime":     "Average Time",
+	"colPeriod":      "Period",
+	"colItems":       "Items",
+	"colHours":       "Hours",
+	"periodTitle":    "Over Time",
+	"periodDay":      "By Day",
+	"periodWeek":     "By Week",
+	"periodMonth":    "By Month",
+	"undatedHint":    "%d item(s) without a recognised date are left out of this table.",
+	"showDetails":    "Show Details",
+	"hideDetails":    "Hide Details",
+	"exportLabel":    "Export:",
+	"exportSummary":  "Summary CSV",
+	"shareLink":      "Create link",
+	"detailsTitle":   "Task Details",
+	"colDate":        "Date",
+	"colID":          "ID",
+	"colCategory":    "Category",
+	"colDuration":    "Duration",
+	"colTaskRate":    "Rate",
+	"colType":        "Type",
+	"colStatus":      "Status",
+}
+
+// messagesPT is the Brazilian Portuguese catalog.
+var messagesPT = map[string]string{
+	// Page and input form
+	"pageTitle":        "Analisador de Tarefas",
+	"language":         "Idioma",
+	"howToUse":         "Como Usar",
+	"howToUseAlt":      "Demonstração de uso",
+	"pasteText":        "Colar Texto",
+	"uploadFile":       "Carregar CSV",
+	"pastePlaceholder": "Cole o texto do relatório aqui...",
+	"dropFile":         "Arraste e solte o arquivo CSV aqui",
+	"or":               "ou",
+	"chooseFile":       "Escolher Arquivo",
+	"saveHistory":      "Salvar no histórico",
+	"profileLabel":     "Mapeamento de colunas:",
+	"profileNone":      "Colunas padrão",
+	"analyze":          "Analisar",
+	"viewOnGitHub":     "Ver no GitHub",
+
+	// History
+	"historyLabel":      "Histórico (%d tarefas):",
+	"historyFrom":       "De",
+	"historyTo":         "Até",
+	"viewHistory":       "Ver histórico",
+	"historyEmpty":      "Nenhuma tarefa com data no histórico para este período.",
+	"historySaveFailed": "Não foi possível salvar no histórico.",
+	"historyUpdated":    "Histórico atualizado: %d novas, %d atualizadas, %d sem alteração (%d no total).",
+
+	// Permalinks and format detection
+	"permalink":      "Link deste resultado:",
+	"detectedFormat": "Formato detectado:",
+	"confidence":     "(confiança %s)",
+	"formatCSV":      "CSV",
+	"formatTSV":      "TSV (tabulação)",
+	"formatSemi":     "CSV (ponto e vírgula)",
+	"formatText":     "Texto (blocos de 8 linhas)",
+	"formatLabeled":  "Texto (Chave: valor)",
+	"formatJSON":     "JSON",
+
+	// Format detection reasons. The parser fills in every argument as a string.
+	"reasonSpecified": "formato informado explicitamente",
+	"reasonEmpty":     "entrada vazia",
+	"reasonFallback":  "nenhuma estrutura conhecida; lido como blocos de texto",
+	"reasonJSON":      "documento JSON válido",
+	"reasonNDJSON":    "um objeto JSON por linha (NDJSON)",
+	"reasonBadJSON":   "começa como JSON, mas só %s de %s linhas são JSON válido",
+	"reasonHeader":    "o cabeçalho tem %s colunas conhecidas separadas por %s",
+	"reasonLabeled":   "%s de %s linhas são pares 'Chave: valor'",
+	"reasonText":      "%s de %s linhas pertencem a blocos de tarefa de 8 linhas",
+	"reasonShape":     "%s colunas consistentes separadas por %s, mas sem cabeçalho conhecido",
+
+	// Parse warnings
+	"diagnosticsTitle": "⚠️ Avisos de Leitura (%d)",
+	"diagnosticsNote":  "Linhas descartadas ou com campos zerados durante a leitura. Confira antes de confiar nos totais.",
+	"colLine":          "Linha",
+	"colSeverity":      "Gravidade",
+	"colField":         "Campo",
+	"colMessage":       "Mensagem",
+	"colContent":       "Conteúdo",
+
+	// Parse warning messages. The parser fills in every argument as a string.
+	"diagRead":          "não foi possível ler a entrada: %s",
+	"diagHeader":        "não foi possível ler o cabeçalho do CSV: %s",
+	"diagNoValueCol":    "nenhuma coluna payout/value no cabeçalho; todos os valores serão 0",
+	"diagRowDropped":    "linha descartada: %s",
+	"diagJSON":          "não foi possível ler o JSON: %s",
+	"diagNotObject":     "entrada ignorada: não é um objeto JSON: %s",
+	"diagNotDocument":   "entrada ignorada: o JSON não é um objeto nem uma lista",
+	"diagNotScalar":     "'%s' não é texto nem número; ignorado",
+	"diagIncomplete":    "linha ignorada: bloco de tarefa incompleto",
+	"diagIncompletes":   "linhas %s-%s ignoradas: bloco de tarefa incompleto",
+	"diagNoBlock":       "linha ignorada: não faz parte de uma tarefa",
+	"diagNoBlocks":      "linhas %s-%s ignoradas: não fazem parte de uma tarefa",
+	"diagNoLabels":      "linha ignorada: bloco rotulado sem ID, Duration ou Value",
+	"diagNoValueLabel":  "bloco sem rótulo Value; valor definido como 0",
+	"diagNoValue":       "nenhum valor encontrado; definido como 0",
+	"diagAmount":        "%s '%s' não reconhecido; definido como 0",
+	"diagAmbiguous":     "%s '%s' pode ser lido de duas formas; lido como %s",
+	"diagCurrency":      "moeda desconhecida '%s'; ignorada",
+	"diagValueCurrency": "o valor está em %s, mas os outros valores da tarefa estão em %s; tarefa contada em %s",
+	"diagFieldCurrency": "%s está em %s, mas os outros valores da tarefa estão em %s; tarefa contada em %s",
+	"diagDuration":      "%s; contado como 0 minutos",
+	"diagMoneyDuration": "duração '%s' parece um valor em dinheiro; ignorada",
+	"diagDate":          "data '%s' não reconhecida; tarefa fora do agrupamento e da ordenação por data",
+	"diagTaskType":      "tipo de tarefa desconhecido '%s'; contado como Outros",
+	"diagPayType":       "tipo de pagamento desconhecido '%s'; contado como Outros",
+	"diagStatus":        "status desconhecido '%s'; contado como pendente",
+	"logParseText":      "Iniciando ParseText com %d caracteres de texto",
+	"logSplitLines":     "Dividido em %d linhas",
+
+	// Currencies
+	"currencyTitle":    "⚠️ Várias Moedas",
+	"currencyWarning":  "As tarefas estão em mais de uma moeda. Os totais e médias abaixo somam tudo sem conversão; use os valores por moeda desta tabela.",
+	"colCurrency":      "Moeda",
+	"noCurrency":       "(não informada)",
+	"convertedValue":   "≈ %s em %s",
+	"unconvertedCount": "%d tarefa(s) sem cotação ficaram de fora",
+	"missingRates":     "Sem cotação para: %s",
+	"rateUndated":      "%s sem data",
+	"rateNoCurrency":   "moeda desconhecida",
+
+	// Overview
+	"overview":          "Visão Geral",
+	"overviewHistory":   "Visão Geral do Histórico",
+	"overviewSince":     " desde %s",
+	"overviewUntil":     " até %s",
+	"totalTasks":        "Tarefas Totais",
+	"hoursWorked":       "Horas Trabalhadas",
+	"totalValue":        "Valor Total",
+	"averagesTitle":     "Médias por Tarefa",
+	"avgTime":           "Tempo médio por tarefa",
+	"avgValue":          "Valor médio por tarefa",
+	"avgRate":           "Valor médio por hora",
+	"valueDistribution": "Distribuição de Valores",
+	"typeTasks":         "Tarefas (Task)",
+	"typeExceeded":      "Tempo Excedido",
+	"typeOther":         "Outros",
+
+	// Earnings by status
+	"statusConfirmed":    "Confirmado (pago %s + aprovado %s)",
+	"statusPending":      "Pendente (%d itens)",
+	"statusRejected":     "Rejeitado (%d itens)",
+	"expectedHint":       "Esperado (confirmado + pendente): %s. O valor total acima inclui itens rejeitados.",
+	"statusNamePaid":     "pago",
+	"statusNameApproved": "aprovado",
+	"statusNamePending":  "pendente",
+	"statusNameRejected": "rejeitado",
+
+	// Hours
+	"hoursByType":     "Detalhamento de Horas por Tipo",
+	"hoursChartTitle": "Distribuição de Horas por Tipo",
+	"hoursTasks":      "Horas em Tarefas (Task)",
+	"hoursExceeded":   "Horas em Tempo Excedido",
+	"hoursOther":      "Horas em Outros",
+	"hoursUnit":       "horas",
+	"hoursDuration":   "%s %s (%dh %dmin)",
+	"perHourShort":    "/hora",
+	"minutesValue":    "%s min",
+	"minSec":          "%dmin %ds",
+
+	// Projects
+	"projectsTitle":  "Desempenho por Projeto",
+	"sortHint":       "Clique no cabeçalho de uma coluna para ordenar.",
+	"noProject":      "(sem projeto)",
+	"colProject":     "Projeto",
+	"colTasks":       "Tarefas",
+	"colTotalHours":  "Horas Totais",
+	"colTaskHours":   "Horas em Tarefas",
+	"colExceededHrs": "Horas Excedidas",
+	"colValue":       "Valor",
+	"colRate":        "Valor/Hora",
+	"colAvgTime":     "Tempo Médio",
+	"colPeriod":      "Período",
+	"colItems":       "Itens",
+	"colHours":       "Horas",
+	"periodTitle":    "Evolução no Período",
+	"periodDay":      "Por Dia",
+	"periodWeek":     "Por Semana",
+	"periodMonth":    "Por Mês",
+	"undatedHint":    "%d item(ns) sem data reconhecida ficaram fora desta tabela.",
+	"showDetails":    "Mostrar Detalhes",
+	"hideDetails":    "Ocultar Detalhes",
+	"exportLabel":    "Exportar:",
+	"exportSummary":  "Resumo CSV",
+	"shareLink":      "Gerar link",
+	"detailsTitle":   "Detalhes das Tarefas",
+	"colDate":        "Data",
+	"colID":          "ID",
+	"colCategory":    "Categoria",
+	"colDuration":    "Duração",
+	"colTaskRate":    "Taxa",
+	"colType":        "Tipo",
+	"colStatus":      "Status",
+}
@@ -0,0 +1,214 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// currencySymbols maps currency symbols onto ISO 4217 codes. Longer symbols
+// come first so that "R$" is not read as "$".
+var currencySymbols = []struct{ symbol, code string }{
+	{"US$", "USD"},
+	{"R$", "BRL"},
+	{"C$", "CAD"},
+	{"A$", "AUD"},
+	{"€", "EUR"},
+	{"£", "GBP"},
+	{"¥", "JPY"},
+	{"$", "USD"},
+}
+
+// currencyCodes are the ISO 4217 codes accepted written out next to an
+// amount, e.g. "26.50 EUR" or "BRL 26,50".
+var currencyCodes = map[string]bool{
+	"USD": true, "BRL": true, "EUR": true, "GBP": true, "CAD": true, "AUD": true,
+	"JPY": true, "MXN": true, "ARS": true, "CHF": true, "INR": true, "CNY": true,
+}
+
+// CurrencySymbol returns the usual symbol for an ISO 4217 code ("BRL" gives
+// "R$"), or the code itself if it has none in the table.
+func CurrencySymbol(code string) string {
+	for _, c := range currencySymbols {
+		if c.code == code && (c.symbol == "$" || code != "USD") {
+			return c.symbol
+		}
+	}
+	return code
+}
+
+// splitCurrency separates a currency symbol or code from an amount at either
+// end, e.g. "R$ 26,50", "€12.00", "26.50 EUR", "-$2.05", "$-2.05" or
+// "($2.05)". code is empty if the text carries no currency.
+func splitCurrency(s string) (amount, code string) {
+	s = strings.TrimSpace(s)
+	if len(s) > 2 && s[0] == '(' && s[len(s)-1] == ')' {
+		if amount, code := splitCurrency(s[1 : len(s)-1]); code != "" {
+			return "(" + amount + ")", code
+		}
+		return s, ""
+	}
+	sign := ""
+	if len(s) > 1 && (s[0] == '-' || s[0] == '+') && (s[1] < '0' || s[1] > '9') {
+		sign, s = s[:1], strings.TrimSpace(s[1:])
+	}
+
+	for _, c := range currencySymbols {
+		if rest, ok := strings.CutPrefix(s, c.symbol); ok {
+			return sign + strings.TrimSpace(rest), c.code
+		}
+		if rest, ok := strings.CutSuffix(s, c.symbol); ok {
+			return sign + strings.TrimSpace(rest), c.code
+		}
+	}
+	if len(s) > 3 && currencyCodes[s[:3]] && !isLetter(s[3]) {
+		return sign + strings.TrimSpace(s[3:]), s[:3]
+	}
+	if len(s) > 3 && currencyCodes[s[len(s)-3:]] && !isLetter(s[len(s)-4]) {
+		return sign + strings.TrimSpace(s[:len(s)-3]), s[len(s)-3:]
+	}
+	return sign + s, ""
+}
+
+func isLetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// isCurrencyToken reports whether s is nothing but a currency symbol or code,
+// optionally with a rate suffix, e.g. "R$", "EUR" or "EUR/hr".
+func isCurrencyToken(s string) bool {
+	s = strings.TrimLeft(trimRateSuffix(s), "(-")
+	if currencyCodes[s] {
+		return true
+	}
+	for _, c := range currencySymbols {
+		if s == c.symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCurrency reports whether s is an amount written with a currency symbol or code.
+func hasCurrency(s string) bool {
+	_, code := splitCurrency(trimRateSuffix(s))
+	return code != ""
+}
+
+// isRateToken reports whether s ends in a per-hour suffix such as "/hr".
+func isRateToken(s string) bool {
+	return trimRateSuffix(s) != strings.TrimSpace(s)
+}
+
+// trimRateSuffix removes a trailing "/hr", "/h" or "/hora".
+func trimRateSuffix(s string) string {
+	s = strings.TrimSpace(s)
+	lower := strings.ToLower(s)
+	for _, suffix := range []string{"/hr", "/hora", "/h"} {
+		if strings.HasSuffix(lower, suffix) {
+			return strings.TrimSpace(s[:len(s)-len(suffix)])
+		}
+	}
+	return s
+}
+
+// joinCurrencyTokens rejoins amounts that strings.Fields split apart at the
+// space between symbol and number, so ["R$", "26,50"] becomes ["R$ 26,50"]
+// and ["26.50", "EUR"] becomes ["26.50 EUR"].
+func joinCurrencyTokens(parts []string) []string {
+	var joined []string
+	for i := 0; i < len(parts); i++ {
+		part := parts[i]
+		if !isCurrencyToken(part) {
+			joined = append(joined, part)
+			continue
+		}
+		if i+1 < len(parts) && startsLikeNumber(parts[i+1]) && !isRateToken(part) {
+			joined = append(joined, part+" "+parts[i+1])
+			i++
+			continue
+		}
+		if len(joined) > 0 && startsLikeNumber(joined[len(joined)-1]) {
+			joined[len(joined)-1] += " " + part
+			continue
+		}
+		joined = append(joined, part)
+	}
+	return joined
+}
+
+func startsLikeNumber(s string) bool {
+	s = strings.TrimLeft(s, "+-(")
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+// parseMoney parses an amount with an optional currency symbol or code and
+// rate suffix, e.g. "$26.50/hr", "R$ 1.234,50", "12.00 EUR", "($2.00)" or a
+// plain number, and returns the ISO 4217 code it was written in ("" if none).
+// The currency decides how an ambiguous amount such as "1.234" is read, and
+// style does for an amount without one; guessed reports the latter case.
+// See NumberStyle.
+func parseMoney(s string, style NumberStyle) (money types.Money, code string, guessed bool, err error) {
+	amount, code := splitCurrency(trimRateSuffix(s))
+	plain, guessed, err := normalizeNumber(amount, styleFor(code, style))
+	if err != nil {
+		return 0, code, false, err
+	}
+	money, err = parseAmount(plain)
+	return money, code, guessed && code == "", err
+}
+
+// warnGuessed adds a warning for an amount written without a currency that
+// could be read either way, such as "1.234", saying how it was read.
+func warnGuessed(diags []types.Diagnostic, guessed bool, s string, m types.Money, field string, line int, raw string) []types.Diagnostic {
+	if !guessed {
+		return diags
+	}
+	return addDiagnostic(diags, line, raw, field, types.SeverityWarning,
+		"diagAmbiguous", field, strings.TrimSpace(s), m)
+}
+
+// setCurrencyField reads a currency given as a field of its own ("BRL", "R$")
+// and records it on the task. Unknown currencies get a warning and are ignored.
+func setCurrencyField(diags []types.Diagnostic, task *types.Task, value string, line int, raw string) []types.Diagnostic {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "-" {
+		return diags
+	}
+	if code := strings.ToUpper(value); currencyCodes[code] {
+		return setCurrency(diags, task, code, "currency", line, raw)
+	}
+	for _, c := range currencySymbols {
+		if value == c.symbol {
+			return setCurrency(diags, task, c.code, "currency", line, raw)
+		}
+	}
+	return addDiagnostic(diags, line, raw, "currency", types.SeverityWarning,
+		"diagCurrency", value)
+}
+
+// setCurrency records the currency an amount of the task was written in.
+// The value is what gets summed, so its currency decides the task's whatever
+// order the fields come in: a value in a different currency from one seen
+// earlier replaces it, and any other amount that disagrees keeps the task's
+// currency. Either way the conflict gets a warning.
+func setCurrency(diags []types.Diagnostic, task *types.Task, code, field string, line int, raw string) []types.Diagnostic {
+	if code == "" {
+		return diags
+	}
+	if task.Currency == "" {
+		task.Currency = code
+		return diags
+	}
+	if task.Currency == code {
+		return diags
+	}
+	if field == "value" {
+		diags = addDiagnostic(diags, line, raw, field, types.SeverityWarning,
+			"diagValueCurrency", code, task.Currency, code)
+		task.Currency = code
+		return diags
+	}
+	return addDiagnostic(diags, line, raw, field, types.SeverityWarning,
+		"diagFieldCurrency", field, code, task.Currency, task.Currency)
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in      string
+		style   NumberStyle
+		want    types.Money
+		code    string
+		guessed bool
+		wantErr bool
+	}{
+		{in: "$26.50/hr", want: 2650, code: "USD"},
+		{in: "R$ 26,50", want: 2650, code: "BRL"},
+		{in: "R$ 1.234,50", want: 123450, code: "BRL"},
+		{in: "€12.00", want: 1200, code: "EUR"},
+		{in: "£3.10", want: 310, code: "GBP"},
+		{in: "12.00 EUR", want: 1200, code: "EUR"},
+		{in: "$1,234.56", want: 123456, code: "USD"},
+		{in: "-$2.00", want: -200, code: "USD"},
+		{in: "$-2.00", want: -200, code: "USD"},
+		{in: "($2.00)", want: -200, code: "USD"},
+		{in: "2.00-", want: -200},
+		{in: "26,50", want: 2650},
+		{in: "0.125", want: 13},
+		{in: "9223372036854775.80", want: 922337203685477580},
+		{in: "92233720368547758.07", want: 9223372036854775807},
+
+		// "1.234" follows its currency, else the style, which is then reported
+		{in: "R$ 1.234", style: StyleDot, want: 123400, code: "BRL"},
+		{in: "$1.234", style: StyleComma, want: 123, code: "USD"},
+		{in: "1.234", style: StyleAuto, want: 123, guessed: true},
+		{in: "1.234", style: StyleDot, want: 123, guessed: true},
+		{in: "1.234", style: StyleComma, want: 123400, guessed: true},
+		{in: "1,234", style: StyleDot, want: 123400, guessed: true},
+		{in: "1,234", style: StyleComma, want: 123, guessed: true},
+
+		{in: "", wantErr: true},
+		{in: "$", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "$1,23,4", wantErr: true},
+		{in: "9223372036854775807", wantErr: true},
+		{in: "92233720368547758.08", wantErr: true},
+		{in: "92233720368547758.075", wantErr: true},
+		{in: "-9223372036854775807", wantErr: true},
+		{in: "99999999999999999999999", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, code, guessed, err := parseMoney(tt.in, tt.style)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseMoney(%q, %d) = %v, want an error", tt.in, tt.style, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseMoney(%q, %d): %v", tt.in, tt.style, err)
+			continue
+		}
+		if got != tt.want || code != tt.code || guessed != tt.guessed {
+			t.Errorf("parseMoney(%q, %d) = %v, %q, %v, want %v, %q, %v", tt.in, tt.style, got, code, guessed, tt.want, tt.code, tt.guessed)
+		}
+	}
+}
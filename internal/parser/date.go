@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// dateLayouts are the work-date layouts accepted by ParseDate, tried in order.
+// Slash dates are read day-first ("30/03/2025") and only fall back to
+// month-first when that fails (e.g. "03/30/2025").
+var dateLayouts = []string{
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"Jan 2 2006",
+	"2006-01-02",
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"02/01/2006",
+	"01/02/2006",
+}
+
+// ParseDate reads a work date in any of the platform's layouts: "Mar 30, 2025",
+// ISO dates, "30/03/2025" and RFC3339 timestamps.
+func ParseDate(date string) (time.Time, error) {
+	date = strings.TrimSpace(date)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, date); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %q", date)
+}
+
+// setWorkDate fills task.WorkDate from task.Date, reporting dates that cannot be read.
+func setWorkDate(diags []types.Diagnostic, task *types.Task, line int, raw string) []types.Diagnostic {
+	if task.Date == "" || task.Date == "-" {
+		return diags
+	}
+	t, err := ParseDate(task.Date)
+	if err != nil {
+		return addDiagnostic(diags, line, raw, "date", types.SeverityWarning,
+			"diagDate", task.Date)
+	}
+	task.WorkDate = t
+	return diags
+}
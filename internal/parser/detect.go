@@ -0,0 +1,223 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// Input formats understood by Parse
+const (
+	FormatCSV       = "csv"       // Comma-separated with a header row; another delimiter is picked up from it
+	FormatTSV       = "tsv"       // Tab-separated with a header row
+	FormatSemicolon = "semicolon" // Semicolon-separated with a header row (spreadsheet exports)
+	FormatText      = "text"      // Positional 8-line text blocks
+	FormatLabeled   = "labeled"   // "Key: value" text blocks
+	FormatJSON      = "json"      // JSON array or NDJSON
+)
+
+// Detection is the result of sniffing an input payload.
+type Detection struct {
+	Format     string   `json:"format"`
+	Confidence float64  `json:"confidence"` // 0..1
+	Reason     string   `json:"reason"`     // In English
+	ReasonKey  string   `json:"reasonKey,omitempty"`
+	ReasonArgs []string `json:"reasonArgs,omitempty"` // Arguments to the catalog message ReasonKey
+}
+
+// newDetection returns a Detection whose reason is the catalog message key.
+func newDetection(format string, confidence float64, key string, args ...any) Detection {
+	reason, strArgs := catalogMessage(key, args)
+	return Detection{Format: format, Confidence: confidence, Reason: reason, ReasonKey: key, ReasonArgs: strArgs}
+}
+
+// Specified returns the Detection for a format given by the user rather than
+// detected.
+func Specified(format string) Detection {
+	return newDetection(format, 1, "reasonSpecified")
+}
+
+// delimiters checked by the delimited-format sniffer, with the format each implies.
+var delimiters = []struct {
+	comma  rune
+	format string
+}{
+	{',', FormatCSV},
+	{'\t', FormatTSV},
+	{';', FormatSemicolon},
+}
+
+// sniffLines is how many non-empty lines are inspected for column consistency.
+const sniffLines = 10
+
+// IsFormat reports whether format is one of the known Format constants.
+func IsFormat(format string) bool {
+	switch format {
+	case FormatCSV, FormatTSV, FormatSemicolon, FormatText, FormatLabeled, FormatJSON:
+		return true
+	}
+	return false
+}
+
+// DetectFormat sniffs input and reports which parser should handle it.
+func DetectFormat(input string) Detection {
+	return detectFormat(input, nil)
+}
+
+// detectFormat is DetectFormat with the header names of profile, which may be nil.
+func detectFormat(input string, profile *Profile) Detection {
+	trimmed := strings.TrimSpace(strings.TrimPrefix(input, "\ufeff"))
+	if trimmed == "" {
+		return newDetection(FormatText, 0, "reasonEmpty")
+	}
+	lines := nonEmptyLines(trimmed)
+
+	if d, ok := detectJSON(trimmed, lines); ok {
+		return d
+	}
+	if d, ok := detectDelimitedHeader(lines[0], profile); ok {
+		return d
+	}
+	if d, ok := detectTextBlocks(strings.Split(strings.ReplaceAll(trimmed, "\r\n", "\n"), "\n"), len(lines)); ok {
+		return d
+	}
+	if d, ok := detectDelimitedShape(lines); ok {
+		return d
+	}
+	return newDetection(FormatText, 0.1, "reasonFallback")
+}
+
+// Parse runs the parser for format over input.
+func Parse(format, input string) ([]types.Task, []types.Diagnostic) {
+	var builtin *Profile
+	return builtin.Parse(format, input, StyleAuto)
+}
+
+// sniffDelimiter returns the field delimiter of delimited input: the one that
+// splits the header into the most columns known to the built-in names or
+// profile, which may be nil, else the one that splits the first lines into a
+// consistent number of columns, else a comma.
+func sniffDelimiter(input string, profile *Profile) rune {
+	lines := nonEmptyLines(strings.TrimPrefix(input, "\ufeff"))
+	if len(lines) == 0 {
+		return ','
+	}
+	d, ok := detectDelimitedHeader(lines[0], profile)
+	if !ok {
+		d, ok = detectDelimitedShape(lines)
+	}
+	for _, delim := range delimiters {
+		if ok && delim.format == d.Format {
+			return delim.comma
+		}
+	}
+	return ','
+}
+
+// nonEmptyLines splits s into lines, dropping blank ones and trailing '\r'.
+func nonEmptyLines(s string) []string {
+	var out []string
+	for _, l := range strings.Split(s, "\n") {
+		l = strings.TrimRight(l, "\r")
+		if strings.TrimSpace(l) != "" {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// detectJSON recognises a JSON document or NDJSON (one JSON value per line).
+func detectJSON(trimmed string, lines []string) (Detection, bool) {
+	if trimmed[0] != '[' && trimmed[0] != '{' {
+		return Detection{}, false
+	}
+	if json.Valid([]byte(trimmed)) {
+		return newDetection(FormatJSON, 1, "reasonJSON"), true
+	}
+	valid := 0
+	for _, l := range lines {
+		if json.Valid([]byte(strings.TrimSpace(l))) {
+			valid++
+		}
+	}
+	if valid == len(lines) {
+		return newDetection(FormatJSON, 0.95, "reasonNDJSON"), true
+	}
+	return newDetection(FormatJSON, 0.5, "reasonBadJSON", valid, len(lines)), true
+}
+
+// detectDelimitedHeader looks for a header row with column names known to the
+// built-in names or profile, which may be nil.
+func detectDelimitedHeader(header string, profile *Profile) (Detection, bool) {
+	bestKnown := 0
+	best := delimiters[0]
+	for _, d := range delimiters {
+		known := 0
+		for _, col := range strings.Split(header, string(d.comma)) {
+			if profile.field(strings.Trim(col, " \"")) != "" {
+				known++
+			}
+		}
+		if known > bestKnown {
+			bestKnown, best = known, d
+		}
+	}
+	if bestKnown < 2 {
+		return Detection{}, false
+	}
+	return newDetection(best.format, min(1, 0.5+0.1*float64(bestKnown)), "reasonHeader", bestKnown, fmt.Sprintf("%q", best.comma)), true
+}
+
+// detectTextBlocks measures how much of the input is covered by labeled or
+// positional text blocks. nonEmpty is the number of non-blank lines.
+func detectTextBlocks(lines []string, nonEmpty int) (Detection, bool) {
+	labeled, positional := 0, 0
+	for i := 0; i < len(lines); {
+		if hasTextBlockShape(lines[i:]) {
+			positional += 6 // Non-empty lines in a positional block
+			i += 8
+			continue
+		}
+		if isLabeledLine(lines[i]) {
+			labeled++
+		}
+		i++
+	}
+
+	covered := float64(labeled+positional) / float64(nonEmpty)
+	if covered < 0.5 {
+		return Detection{}, false
+	}
+	if labeled > positional {
+		return newDetection(FormatLabeled, covered, "reasonLabeled", labeled+positional, nonEmpty), true
+	}
+	return newDetection(FormatText, covered, "reasonText", labeled+positional, nonEmpty), true
+}
+
+// detectDelimitedShape is the weak fallback for delimited data without a known
+// header: every sniffed line must have the same number (>1) of columns.
+func detectDelimitedShape(lines []string) (Detection, bool) {
+	sample := lines[:min(len(lines), sniffLines)]
+	if len(sample) < 2 {
+		return Detection{}, false
+	}
+	for _, d := range delimiters {
+		cols := strings.Count(sample[0], string(d.comma)) + 1
+		if cols < 2 {
+			continue
+		}
+		consistent := true
+		for _, l := range sample[1:] {
+			if strings.Count(l, string(d.comma))+1 != cols {
+				consistent = false
+				break
+			}
+		}
+		if consistent {
+			return newDetection(d.format, 0.3, "reasonShape", cols, fmt.Sprintf("%q", d.comma)), true
+		}
+	}
+	return Detection{}, false
+}
@@ -0,0 +1,98 @@
+package parser
+
+import "testing"
+
+const (
+	positionalBlock = "Mar 30, 2025\n67e78d4f24eaa8f13ae8a7d1\nhopper_v2\n\n5m 30s $26.50/hr $2.43\nTask\n\npending\n"
+	labeledBlock    = "Date: Mar 30, 2025\nID: 67e78d4f24eaa8f13ae8a7d1\nCategory: hopper_v2\nDuration: 5m 30s\nRate: $26.50/hr\nValue: $2.43\nType: Task\nStatus: pending\n"
+	csvExport       = "workDate,itemID,duration,rateApplied,payout,payType,projectName,status\n" +
+		"\"Mar 30, 2025\",\"67e78d4f24eaa8f13ae8a7d1\",\"5m 30s\",\"$26.50/hr\",\"$2.43\",\"prepay\",\"hopper_v2\",\"pending\"\n"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		minConf float64 // Lowest acceptable confidence
+		maxConf float64 // Highest acceptable confidence
+	}{
+		{"csv export", csvExport, FormatCSV, 1, 1},
+		{"csv with bom", "\ufeff" + csvExport, FormatCSV, 1, 1},
+		{"tsv", "ID\tDuration\tValue\na\t1h\t5\n", FormatTSV, 0.8, 0.8},
+		{"semicolon", "ID;Duration;Value\na;1h;5,00\n", FormatSemicolon, 0.8, 0.8},
+		{"json array", `[{"id": "a", "value": 5}]`, FormatJSON, 1, 1},
+		{"json object", `{"id": "a", "value": 5}`, FormatJSON, 1, 1},
+		{"ndjson", "{\"id\": \"a\"}\n{\"id\": \"b\"}\n", FormatJSON, 0.95, 0.95},
+		{"broken json", "[{\"id\": \"a\"},\n", FormatJSON, 0.5, 0.5},
+		{"positional blocks", positionalBlock + positionalBlock, FormatText, 1, 1},
+		{"labeled block", labeledBlock, FormatLabeled, 1, 1},
+		{"labeled block with a title", "Tasks for March\nID: a\nValue: $1\n", FormatLabeled, 0.6, 0.7},
+		{"unknown columns", "foo;bar\n1;2\n3;4\n", FormatSemicolon, 0.3, 0.3},
+		{"prose", "nothing to see here\nor here\n", FormatText, 0.1, 0.1},
+		{"empty", "  \n", FormatText, 0, 0},
+	}
+	for _, tt := range tests {
+		got := DetectFormat(tt.input)
+		if got.Format != tt.want || got.Confidence < tt.minConf || got.Confidence > tt.maxConf {
+			t.Errorf("%s: DetectFormat = %s (%.2f, %s), want %s with confidence %.2f-%.2f",
+				tt.name, got.Format, got.Confidence, got.Reason, tt.want, tt.minConf, tt.maxConf)
+		}
+	}
+}
+
+func TestProfileDetectFormat(t *testing.T) {
+	const input = "Task code;Earnings;Time spent\na;5,00;1h\n"
+	profile := &Profile{Columns: map[string]string{"task code": "id", "earnings": "value", "time spent": "duration"}}
+
+	if got := DetectFormat(input); got.Format == FormatSemicolon && got.Confidence > 0.5 {
+		t.Errorf("DetectFormat without the profile = %s (%.2f), want no confident semicolon match", got.Format, got.Confidence)
+	}
+	if got := profile.DetectFormat(input); got.Format != FormatSemicolon || got.Confidence != 0.8 {
+		t.Errorf("Profile.DetectFormat = %s (%.2f), want semicolon (0.80)", got.Format, got.Confidence)
+	}
+}
+
+func TestSniffDelimiter(t *testing.T) {
+	profile := &Profile{Columns: map[string]string{"earnings": "value", "task code": "id"}}
+	tests := []struct {
+		name    string
+		input   string
+		profile *Profile
+		want    rune
+	}{
+		{"comma header", "ID,Duration,Value\na,1h,5\n", nil, ','},
+		{"tab header", "ID\tDuration\tValue\na\t1h\t5\n", nil, '\t'},
+		{"semicolon header", "ID;Duration;Value\na;1h;5,00\n", nil, ';'},
+		{"semicolon header with decimal commas", "ID;Value\na;1,5\n", nil, ';'},
+		{"bom", "\ufeffID;Duration;Value\na;1h;5\n", nil, ';'},
+		{"quoted header", "\"ID\";\"Value\"\n\"a\";\"5\"\n", nil, ';'},
+		{"profile header", "Task code;Earnings\na;5\n", profile, ';'},
+		{"unknown header, consistent shape", "foo;bar\n1;2\n3;4\n", nil, ';'},
+		{"unknown header, tab shape", "foo\tbar\tbaz\n1\t2\t3\n", nil, '\t'},
+		{"nothing to go on", "foo\n", nil, ','},
+		{"empty", "", nil, ','},
+	}
+	for _, tt := range tests {
+		if got := sniffDelimiter(tt.input, tt.profile); got != tt.want {
+			t.Errorf("%s: sniffDelimiter = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseCSVTakesDelimiterFromHeader(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"comma", "ID,Duration,Value\na,1h,5.00\n"},
+		{"tab", "ID\tDuration\tValue\na\t1h\t5.00\n"},
+		{"semicolon", "ID;Duration;Value\na;1h;5,00\n"},
+	}
+	for _, tt := range tests {
+		tasks, diags := Parse(FormatCSV, tt.input)
+		if len(tasks) != 1 || tasks[0].ID != "a" || tasks[0].Value != 500 {
+			t.Errorf("%s: Parse(csv) = %+v, %+v, want one task a worth 5.00", tt.name, tasks, diags)
+		}
+	}
+}
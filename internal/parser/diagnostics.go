@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/i18n"
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// addDiagnostic logs a parse problem and appends it to diags. key names the
+// message in the i18n catalog; args are kept as strings so that the message
+// can be shown in another language after the diagnostic has been saved.
+func addDiagnostic(diags []types.Diagnostic, line int, raw, field, severity, key string, args ...interface{}) []types.Diagnostic {
+	msg, strArgs := catalogMessage(key, args)
+	log.Printf("[%s] Parser: line %d: %s", severity, line, msg)
+	return append(diags, types.Diagnostic{
+		Line:     line,
+		Raw:      raw,
+		Field:    field,
+		Severity: severity,
+		Message:  msg,
+		Key:      key,
+		Args:     strArgs,
+	})
+}
+
+// catalogMessage formats the English catalog message key with args, and
+// returns the args as strings for showing the message in another language.
+func catalogMessage(key string, args []any) (string, []string) {
+	strArgs := make([]string, len(args))
+	msgArgs := make([]any, len(args))
+	for i, arg := range args {
+		strArgs[i] = fmt.Sprint(arg)
+		msgArgs[i] = strArgs[i]
+	}
+	return i18n.English.Tf(key, msgArgs...), strArgs
+}
+
+// csvErrorLine extracts the line number from a csv.ParseError, falling back to def.
+func csvErrorLine(err error, def int) int {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.StartLine
+	}
+	return def
+}
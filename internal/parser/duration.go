@@ -0,0 +1,193 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+// durationUnits maps the unit words accepted after a number onto their length.
+var durationUnits = map[string]time.Duration{
+	"d": day, "day": day, "days": day, "dia": day, "dias": day,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour, "hora": time.Hour, "horas": time.Hour,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute, "minuto": time.Minute, "minutos": time.Minute,
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"seg": time.Second, "segundo": time.Second, "segundos": time.Second,
+}
+
+// ParseTime reads a duration, rounded to the nearest second. It accepts:
+//
+//   - units: "1h 30m 15s", "5m 30s", "1.5h", "90 mins", "2 hours, 5 minutes"
+//   - clock times: "1:05:30" (hours, minutes, seconds) or "05:30" (minutes, seconds)
+//   - ISO 8601 durations: "PT5M30S", "PT1.5H", "P1DT2H"
+//
+// An empty string or the "-" placeholder is 0. Anything else that cannot be
+// read, including a bare number with no unit, is an error.
+func ParseTime(timeStr string) (time.Duration, error) {
+	s := strings.TrimSpace(timeStr)
+	if s == "" || s == "-" {
+		return 0, nil
+	}
+
+	// Components are summed as float nanoseconds so "1.5h" is exact, then
+	// rounded once at the end
+	var total float64
+	var err error
+	switch {
+	case strings.Contains(s, ":"):
+		total, err = parseClockDuration(s)
+	case isISODuration(s):
+		total, err = parseISODuration(s)
+	default:
+		total, err = parseUnitDuration(s)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %v", timeStr, err)
+	}
+	return time.Duration(math.Round(total/float64(time.Second))) * time.Second, nil
+}
+
+// parseClockDuration reads "H:MM:SS" or "MM:SS". Every part after the first
+// must be below 60; the last may have decimals.
+func parseClockDuration(s string) (float64, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("too many ':' separators")
+	}
+	// Unit of each part, from the last one backwards: seconds, minutes, hours
+	scale := []time.Duration{time.Second, time.Minute, time.Hour}
+	var total float64
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || value < 0 {
+			return 0, fmt.Errorf("%q is not a number", part)
+		}
+		if i > 0 && value >= 60 {
+			return 0, fmt.Errorf("%q must be below 60", part)
+		}
+		if i < len(parts)-1 && value != float64(int(value)) {
+			return 0, fmt.Errorf("only the last part may have decimals")
+		}
+		total += value * float64(scale[len(parts)-1-i])
+	}
+	return total, nil
+}
+
+// isISODuration reports whether s starts like an ISO 8601 duration: "P"
+// followed by "T" or a digit.
+func isISODuration(s string) bool {
+	s = strings.ToUpper(s)
+	return len(s) > 1 && s[0] == 'P' && (s[1] == 'T' || s[1] >= '0' && s[1] <= '9')
+}
+
+// parseISODuration reads an ISO 8601 duration made of weeks, days, hours,
+// minutes and seconds, e.g. "PT5M30S" or "P1DT2H". Years and months are
+// rejected because their length varies.
+func parseISODuration(s string) (float64, error) {
+	s = strings.ToUpper(s)
+	rest := s[1:]
+	if rest == "" || rest == "T" {
+		return 0, fmt.Errorf("no components")
+	}
+
+	var total float64
+	inTime := false
+	for rest != "" {
+		if rest[0] == 'T' {
+			if inTime {
+				return 0, fmt.Errorf("more than one 'T'")
+			}
+			inTime, rest = true, rest[1:]
+			continue
+		}
+		end := strings.IndexFunc(rest, func(r rune) bool { return (r < '0' || r > '9') && r != '.' && r != ',' })
+		if end <= 0 {
+			return 0, fmt.Errorf("expected a number before %q", rest)
+		}
+		value, err := strconv.ParseFloat(strings.Replace(rest[:end], ",", ".", 1), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a number", rest[:end])
+		}
+		designator := rest[end]
+		rest = rest[end+1:]
+
+		var unit time.Duration
+		switch {
+		case !inTime && designator == 'W':
+			unit = 7 * day
+		case !inTime && designator == 'D':
+			unit = day
+		case !inTime && (designator == 'Y' || designator == 'M'):
+			return 0, fmt.Errorf("years and months are not fixed lengths of time")
+		case inTime && designator == 'H':
+			unit = time.Hour
+		case inTime && designator == 'M':
+			unit = time.Minute
+		case inTime && designator == 'S':
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf("unexpected %q", string(designator))
+		}
+		total += value * float64(unit)
+	}
+	return total, nil
+}
+
+// parseUnitDuration reads numbers each followed by a unit, e.g. "1h 30m",
+// "1.5h", "2 hours, 5 minutes" or "1h30". A bare number is only accepted right
+// after an hour value, as minutes.
+func parseUnitDuration(s string) (float64, error) {
+	s = strings.ToLower(s)
+	var total float64
+	var lastUnit time.Duration
+	for i := 0; i < len(s); {
+		// Separators between the components
+		if c := s[i]; c == ' ' || c == ',' || c == '+' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(s[i:], "and ") || strings.HasPrefix(s[i:], "e ") {
+			i += strings.IndexByte(s[i:], ' ') + 1
+			continue
+		}
+
+		start := i
+		for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.' || s[i] == ',') {
+			i++
+		}
+		if start == i {
+			return 0, fmt.Errorf("expected a number at %q", s[start:])
+		}
+		value, err := strconv.ParseFloat(strings.Replace(s[start:i], ",", ".", 1), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a number", s[start:i])
+		}
+
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		unitStart := i
+		for i < len(s) && isLetter(s[i]) {
+			i++
+		}
+		unit := s[unitStart:i]
+		if unit == "" {
+			if lastUnit == time.Hour && i == len(s) {
+				total += value * float64(time.Minute) // "1h30"
+				break
+			}
+			return 0, fmt.Errorf("number %s has no unit", s[start:unitStart])
+		}
+		scale, ok := durationUnits[unit]
+		if !ok {
+			return 0, fmt.Errorf("unknown unit %q", unit)
+		}
+		total += value * float64(scale)
+		lastUnit = scale
+	}
+	return total, nil
+}
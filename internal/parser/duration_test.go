@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "", want: 0},
+		{in: "-", want: 0},
+		{in: "  ", want: 0},
+
+		// Units
+		{in: "1h 30m 15s", want: time.Hour + 30*time.Minute + 15*time.Second},
+		{in: "5m 30s", want: 5*time.Minute + 30*time.Second},
+		{in: "1.5h", want: 90 * time.Minute},
+		{in: "1,5h", want: 90 * time.Minute},
+		{in: "90 mins", want: 90 * time.Minute},
+		{in: "2 hours, 5 minutes", want: 2*time.Hour + 5*time.Minute},
+		{in: "1 hour and 5 minutes", want: time.Hour + 5*time.Minute},
+		{in: "2 horas e 10 minutos", want: 2*time.Hour + 10*time.Minute},
+		{in: "1h30", want: 90 * time.Minute},
+		{in: "1h 30", want: 90 * time.Minute},
+		{in: "1H 2M", want: time.Hour + 2*time.Minute},
+		{in: "2d", want: 48 * time.Hour},
+		{in: "0.1s", want: 0},
+		{in: "0.5s", want: time.Second},
+		{in: "1.0001m", want: time.Minute},
+
+		// Clock times
+		{in: "1:05:30", want: time.Hour + 5*time.Minute + 30*time.Second},
+		{in: "05:30", want: 5*time.Minute + 30*time.Second},
+		{in: "0:00:01.6", want: 2 * time.Second},
+		{in: "25:00:00", want: 25 * time.Hour},
+
+		// ISO 8601
+		{in: "PT5M30S", want: 5*time.Minute + 30*time.Second},
+		{in: "PT1.5H", want: 90 * time.Minute},
+		{in: "P1DT2H", want: 26 * time.Hour},
+		{in: "P1W", want: 7 * 24 * time.Hour},
+		{in: "pt10m", want: 10 * time.Minute},
+
+		{in: "90", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "5 parsecs", wantErr: true},
+		{in: "30 1h", wantErr: true},
+		{in: "1h 30 5s", wantErr: true},
+		{in: "1:2:3:4", wantErr: true},
+		{in: "1:60", wantErr: true},
+		{in: "1.5:30", wantErr: true},
+		{in: "a:30", wantErr: true},
+		{in: "PT", wantErr: true},
+		{in: "P1M", wantErr: true},
+		{in: "P1Y", wantErr: true},
+		{in: "PT1HT2M", wantErr: true},
+		{in: "PT1X", wantErr: true},
+		{in: "PTH", wantErr: true},
+		{in: "$26.50", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTime(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseTime(%q) = %v, want an error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseTime(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTime(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Text encodings recognised by DecodeInput
+const (
+	EncodingUTF8    = "UTF-8"
+	EncodingUTF16LE = "UTF-16LE"
+	EncodingUTF16BE = "UTF-16BE"
+	EncodingCP1252  = "Windows-1252" // Also covers Latin-1 (ISO 8859-1) text
+)
+
+// cp1252 maps the bytes 0x80-0x9F onto the characters Windows-1252 gives them.
+// The five bytes it leaves undefined keep their Latin-1 control codes.
+var cp1252 = [32]rune{
+	'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 'Ž', 0x8F,
+	0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9D, 'ž', 'Ÿ',
+}
+
+// DecodeInput converts an uploaded file to UTF-8 text and reports the
+// encoding it was read as. Spreadsheets often re-save exports as UTF-16 or in
+// the Windows code page, and may add a byte order mark; a BOM is removed so
+// that it does not end up in the first header name. Without a BOM, UTF-16 is
+// recognised by its zero bytes, and anything that is not valid UTF-8 is read
+// as Windows-1252.
+func DecodeInput(data []byte) (string, string) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		return string(data[3:]), EncodingUTF8
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return decodeUTF16(data[2:], binary.LittleEndian), EncodingUTF16LE
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return decodeUTF16(data[2:], binary.BigEndian), EncodingUTF16BE
+	}
+
+	switch sniffUTF16(data) {
+	case EncodingUTF16LE:
+		return decodeUTF16(data, binary.LittleEndian), EncodingUTF16LE
+	case EncodingUTF16BE:
+		return decodeUTF16(data, binary.BigEndian), EncodingUTF16BE
+	}
+	if utf8.Valid(data) {
+		return string(data), EncodingUTF8
+	}
+	return decodeCP1252(data), EncodingCP1252
+}
+
+// sniffUTF16 recognises UTF-16 without a BOM and returns its encoding, or ""
+// if data does not look like UTF-16. Task exports are mostly ASCII, which in
+// UTF-16 makes every other byte zero: the odd ones in little-endian and the
+// even ones in big-endian.
+func sniffUTF16(data []byte) string {
+	sample := data[:min(len(data), 1024)&^1]
+	if len(sample) < 4 {
+		return ""
+	}
+	var evenZeros, oddZeros int
+	for i := 0; i < len(sample); i += 2 {
+		if sample[i] == 0 {
+			evenZeros++
+		}
+		if sample[i+1] == 0 {
+			oddZeros++
+		}
+	}
+	pairs := len(sample) / 2
+	switch {
+	case oddZeros*10 >= pairs*7 && evenZeros*10 < pairs:
+		return EncodingUTF16LE
+	case evenZeros*10 >= pairs*7 && oddZeros*10 < pairs:
+		return EncodingUTF16BE
+	}
+	return ""
+}
+
+// decodeUTF16 decodes UTF-16 text in the given byte order, dropping a trailing
+// odd byte and a BOM left at the start.
+func decodeUTF16(data []byte, order binary.ByteOrder) string {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[2*i:])
+	}
+	return strings.TrimPrefix(string(utf16.Decode(units)), "\ufeff")
+}
+
+// decodeCP1252 decodes Windows-1252 text. Bytes outside 0x80-0x9F are the same
+// characters as in Latin-1.
+func decodeCP1252(data []byte) string {
+	var b strings.Builder
+	b.Grow(len(data) + len(data)/8)
+	for _, c := range data {
+		if c >= 0x80 && c <= 0x9F {
+			b.WriteRune(cp1252[c-0x80])
+		} else {
+			b.WriteRune(rune(c))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"encoding/binary"
+	"testing"
+	"unicode/utf16"
+)
+
+// encodeUTF16 encodes s as UTF-16 in the given byte order, without a BOM.
+func encodeUTF16(s string, order binary.ByteOrder) []byte {
+	units := utf16.Encode([]rune(s))
+	data := make([]byte, 2*len(units))
+	for i, u := range units {
+		order.PutUint16(data[2*i:], u)
+	}
+	return data
+}
+
+func TestDecodeInput(t *testing.T) {
+	const text = "ID\tDuration\tValue\na\t1h\tR$ 5,00\nRevisão\t2h\t€1\n"
+	tests := []struct {
+		name     string
+		data     []byte
+		want     string
+		encoding string
+	}{
+		{"utf-8", []byte(text), text, EncodingUTF8},
+		{"utf-8 bom", append([]byte{0xEF, 0xBB, 0xBF}, text...), text, EncodingUTF8},
+		{"utf-16le bom", append([]byte{0xFF, 0xFE}, encodeUTF16(text, binary.LittleEndian)...), text, EncodingUTF16LE},
+		{"utf-16be bom", append([]byte{0xFE, 0xFF}, encodeUTF16(text, binary.BigEndian)...), text, EncodingUTF16BE},
+		{"utf-16le", encodeUTF16(text, binary.LittleEndian), text, EncodingUTF16LE},
+		{"utf-16be", encodeUTF16(text, binary.BigEndian), text, EncodingUTF16BE},
+		{"utf-16le odd byte", append(encodeUTF16("ID;Value\n", binary.LittleEndian), 'x'), "ID;Value\n", EncodingUTF16LE},
+		{"cp1252", []byte("Revis\xe3o;\x80 5;\x93ok\x94\n"), "Revisão;€ 5;“ok”\n", EncodingCP1252},
+		{"cp1252 undefined byte", []byte("a\x81b\xff"), "a\u0081bÿ", EncodingCP1252},
+		{"empty", nil, "", EncodingUTF8},
+	}
+	for _, tt := range tests {
+		got, encoding := DecodeInput(tt.data)
+		if got != tt.want || encoding != tt.encoding {
+			t.Errorf("%s: DecodeInput = %q, %s, want %q, %s", tt.name, got, encoding, tt.want, tt.encoding)
+		}
+	}
+}
+
+func TestSniffUTF16(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"little-endian ascii", encodeUTF16("ID,Value\na,1\n", binary.LittleEndian), EncodingUTF16LE},
+		{"big-endian ascii", encodeUTF16("ID,Value\na,1\n", binary.BigEndian), EncodingUTF16BE},
+		{"utf-8", []byte("ID,Value\na,1\n"), ""},
+		{"too short", []byte{'a', 0}, ""},
+		{"latin-1 in utf-16", encodeUTF16("ãõçéêíóú", binary.LittleEndian), EncodingUTF16LE},
+		{"cp1252 accents", []byte("Revis\xe3o a\xe7\xe3o"), ""},
+		{"zeros on both sides", []byte{0, 0, 0, 0, 0, 0, 0, 0}, ""},
+	}
+	for _, tt := range tests {
+		if got := sniffUTF16(tt.data); got != tt.want {
+			t.Errorf("%s: sniffUTF16 = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
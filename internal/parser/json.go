@@ -0,0 +1,265 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// jsonWrapperKeys are the fields checked for a task array when the top-level
+// JSON value is an object, as returned by the platform's API.
+var jsonWrapperKeys = []string{"tasks", "data", "items", "results"}
+
+// ParseJSON reads tasks from a JSON array of task objects, an API response
+// object wrapping such an array, or NDJSON with one task object per line.
+// Keys use the same names as the CSV header (workDate, payout, payType, ...)
+// or the json tags on types.Task. Numeric durations are read as minutes.
+func ParseJSON(file io.Reader) ([]types.Task, []types.Diagnostic) {
+	return parseJSON(file, StyleAuto)
+}
+
+// parseJSON is ParseJSON reading ambiguous amount strings in style.
+func parseJSON(file io.Reader, style NumberStyle) ([]types.Task, []types.Diagnostic) {
+	var tasks []types.Task
+	var diags []types.Diagnostic
+
+	input, err := io.ReadAll(file)
+	if err != nil {
+		return tasks, addDiagnostic(diags, 0, "", "", types.SeverityError, "diagJSON", err)
+	}
+	input = bytes.TrimPrefix(input, []byte("\ufeff"))
+	trimmed := bytes.TrimSpace(input)
+	if len(trimmed) == 0 {
+		return tasks, diags
+	}
+
+	// Whole-document JSON: an array, or an object that is either a wrapper or a single task
+	if json.Valid(trimmed) {
+		if trimmed[0] != '[' && trimmed[0] != '{' { // A lone string, number, true, false or null
+			return tasks, addDiagnostic(diags, 1, string(trimmed), "", types.SeverityError, "diagNotDocument")
+		}
+		for _, el := range jsonElements(input) {
+			task, taskDiags := parseJSONTask(el.raw, el.line, style)
+			diags = append(diags, taskDiags...)
+			if task != nil {
+				tasks = append(tasks, *task)
+			}
+		}
+		log.Printf("Parsed %d tasks from JSON.\n", len(tasks))
+		return tasks, diags
+	}
+
+	// NDJSON: one task object per line
+	for i, line := range strings.Split(string(input), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		task, taskDiags := parseJSONTask(json.RawMessage(line), i+1, style)
+		diags = append(diags, taskDiags...)
+		if task != nil {
+			tasks = append(tasks, *task)
+		}
+	}
+
+	log.Printf("Parsed %d tasks from NDJSON.\n", len(tasks))
+	return tasks, diags
+}
+
+// jsonElement is one candidate task object and the line it starts on.
+type jsonElement struct {
+	raw  json.RawMessage
+	line int
+}
+
+// jsonElements returns the task objects in a valid JSON document, which must
+// be an array or an object. Arrays are walked with a streaming decoder so each
+// element keeps its line number.
+func jsonElements(input []byte) []jsonElement {
+	start := bytes.IndexAny(input, "[{")
+	if input[start] == '{' {
+		var wrapper map[string]json.RawMessage
+		if err := json.Unmarshal(input, &wrapper); err == nil {
+			for _, key := range jsonWrapperKeys {
+				for k, v := range wrapper {
+					if strings.EqualFold(k, key) && bytes.HasPrefix(bytes.TrimSpace(v), []byte("[")) {
+						// Re-locate the array in the input so line numbers stay accurate
+						offset := bytes.Index(input, v)
+						if offset < 0 {
+							offset = 0
+						}
+						return arrayElements(input, offset)
+					}
+				}
+			}
+		}
+		return []jsonElement{{raw: json.RawMessage(input), line: lineAt(input, start)}}
+	}
+	return arrayElements(input, start)
+}
+
+// arrayElements decodes the JSON array starting at offset into its elements.
+func arrayElements(input []byte, offset int) []jsonElement {
+	var elements []jsonElement
+	dec := json.NewDecoder(bytes.NewReader(input[offset:]))
+	if _, err := dec.Token(); err != nil { // Opening '['
+		return elements
+	}
+	for dec.More() {
+		pos := offset + int(dec.InputOffset())
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			break
+		}
+		elements = append(elements, jsonElement{raw: raw, line: lineAt(input, skipSeparators(input, pos))})
+	}
+	return elements
+}
+
+// skipSeparators advances pos past whitespace and commas between array elements.
+func skipSeparators(input []byte, pos int) int {
+	for pos < len(input) && strings.IndexByte(" \t\r\n,", input[pos]) >= 0 {
+		pos++
+	}
+	return pos
+}
+
+// lineAt returns the 1-based line number of byte offset pos in input.
+func lineAt(input []byte, pos int) int {
+	return bytes.Count(input[:min(pos, len(input))], []byte("\n")) + 1
+}
+
+// parseJSONTask maps a single JSON task object onto types.Task.
+func parseJSONTask(raw json.RawMessage, line int, style NumberStyle) (*types.Task, []types.Diagnostic) {
+	var diags []types.Diagnostic
+	rawText := string(bytes.TrimSpace(raw))
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		diags = addDiagnostic(diags, line, rawText, "", types.SeverityError, "diagNotObject", err)
+		return nil, diags
+	}
+
+	// Walk keys in a fixed order so diagnostics are stable between runs
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	task := &types.Task{Duration: "-"}
+	for _, key := range keys {
+		value := obj[key]
+		field := csvHeaderField(key)
+		if field == "" && strings.EqualFold(key, "durationMins") {
+			field = "durationMins"
+		}
+		if field == "" {
+			continue // Ignore unrelated API fields
+		}
+
+		str, num, isNum, ok := jsonScalar(value)
+		if !ok {
+			diags = addDiagnostic(diags, line, rawText, field, types.SeverityWarning,
+				"diagNotScalar", key)
+			continue
+		}
+
+		switch field {
+		case "date":
+			task.Date = str
+			diags = setWorkDate(diags, task, line, rawText)
+		case "id":
+			task.ID = str
+		case "category":
+			task.Category = str
+		case "status":
+			diags = setStatus(diags, task, str, line, rawText)
+		case "type":
+			taskType, known := normalizeType(str)
+			task.Type = taskType
+			if !known {
+				diags = addDiagnostic(diags, line, rawText, "type", types.SeverityWarning,
+					"diagPayType", str)
+			}
+		case "durationMins":
+			if task.Elapsed == 0 && isNum {
+				task.Elapsed = types.DurationFromMinutes(num)
+				if task.Duration == "-" {
+					task.Duration = formatElapsed(task.Elapsed)
+				}
+			}
+		case "duration":
+			if isNum {
+				task.Elapsed = types.DurationFromMinutes(num)
+				task.Duration = formatElapsed(task.Elapsed)
+			} else if str != "" && str != "-" {
+				task.Duration = str
+				elapsed, err := ParseTime(str)
+				if err != nil {
+					diags = addDiagnostic(diags, line, rawText, "duration", types.SeverityWarning,
+						"diagDuration", err)
+				}
+				task.Elapsed = elapsed
+			}
+		case "currency":
+			diags = setCurrencyField(diags, task, str, line, rawText)
+		case "rate", "value":
+			var amount types.Money
+			if str != "" && str != "-" {
+				// A JSON number always has a decimal point, whatever the locale
+				amountStyle := style
+				if isNum {
+					amountStyle = StyleDot
+				}
+				var currency string
+				var guessed bool
+				var err error
+				if amount, currency, guessed, err = parseMoney(str, amountStyle); err != nil && isNum {
+					amount = types.MoneyFromFloat(num) // Exponent notation and the like
+				} else if err != nil {
+					diags = addDiagnostic(diags, line, rawText, field, types.SeverityWarning,
+						"diagAmount", field, str)
+				}
+				diags = warnGuessed(diags, guessed && !isNum, str, amount, field, line, rawText)
+				diags = setCurrency(diags, task, currency, field, line, rawText)
+			}
+			if field == "rate" {
+				task.Rate = amount
+			} else {
+				task.Value = amount
+			}
+		}
+	}
+
+	return task, diags
+}
+
+// jsonScalar decodes a JSON string or number. For numbers str holds the
+// literal text so amounts can be read exactly; for strings that hold a
+// number, isNum stays false.
+func jsonScalar(value json.RawMessage) (str string, num float64, isNum bool, ok bool) {
+	if err := json.Unmarshal(value, &str); err == nil {
+		return strings.TrimSpace(str), 0, false, true
+	}
+	if err := json.Unmarshal(value, &num); err == nil {
+		return strings.TrimSpace(string(value)), num, true, true
+	}
+	if string(bytes.TrimSpace(value)) == "null" {
+		return "", 0, false, true
+	}
+	return "", 0, false, false
+}
+
+// formatElapsed renders a numeric duration as "Xm Ys".
+func formatElapsed(d time.Duration) string {
+	totalSeconds := int64(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%dm %ds", totalSeconds/60, totalSeconds%60)
+}
@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+func TestParseJSONDocuments(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		ids     []string
+		errDiag string // Key of the expected error diagnostic, if any
+	}{
+		{name: "array", input: `[{"id": "a"}, {"id": "b"}]`, ids: []string{"a", "b"}},
+		{name: "single object", input: `{"id": "a"}`, ids: []string{"a"}},
+		{name: "wrapper", input: `{"tasks": [{"id": "a"}]}`, ids: []string{"a"}},
+		{name: "ndjson", input: "{\"id\": \"a\"}\n{\"id\": \"b\"}\n", ids: []string{"a", "b"}},
+		{name: "empty", input: "  "},
+		{name: "string", input: `"x"`, errDiag: "diagNotDocument"},
+		{name: "string with a bracket", input: `"a[b"`, errDiag: "diagNotDocument"},
+		{name: "number", input: `123`, errDiag: "diagNotDocument"},
+		{name: "null", input: `null`, errDiag: "diagNotDocument"},
+		{name: "true", input: ` true `, errDiag: "diagNotDocument"},
+		{name: "array of scalars", input: `[1, "x"]`, errDiag: "diagNotObject"},
+	}
+	for _, tt := range tests {
+		tasks, diags := ParseJSON(strings.NewReader(tt.input))
+		var ids []string
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		if strings.Join(ids, ",") != strings.Join(tt.ids, ",") {
+			t.Errorf("%s: ParseJSON IDs = %v, want %v", tt.name, ids, tt.ids)
+		}
+		if tt.errDiag != "" && !hasDiagnostic(diags, tt.errDiag, types.SeverityError) {
+			t.Errorf("%s: ParseJSON diagnostics = %+v, want a %s error", tt.name, diags, tt.errDiag)
+		}
+	}
+}
+
+func TestParseJSONAmounts(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		style   NumberStyle
+		value   types.Money
+		rate    types.Money
+		guessed bool // An ambiguity warning is expected
+	}{
+		{name: "numbers, pt-BR", input: `{"value": 1.234, "rate": 20.125}`, style: StyleComma, value: 123, rate: 2013},
+		{name: "numbers, en", input: `{"value": 1.234, "rate": 20.125}`, style: StyleDot, value: 123, rate: 2013},
+		{name: "whole number, pt-BR", input: `{"value": 1234}`, style: StyleComma, value: 123400},
+		{name: "exponent, pt-BR", input: `{"value": 1.5e3}`, style: StyleComma, value: 150000},
+		{name: "string, pt-BR", input: `{"value": "1.234"}`, style: StyleComma, value: 123400, guessed: true},
+		{name: "string, en", input: `{"value": "1.234"}`, style: StyleDot, value: 123, guessed: true},
+		{name: "string with comma, pt-BR", input: `{"value": "26,50"}`, style: StyleComma, value: 2650},
+		{name: "string with currency, pt-BR", input: `{"value": "$1.234"}`, style: StyleComma, value: 123},
+	}
+	for _, tt := range tests {
+		tasks, diags := parseJSON(strings.NewReader(tt.input), tt.style)
+		if len(tasks) != 1 {
+			t.Errorf("%s: parseJSON = %d tasks, want 1", tt.name, len(tasks))
+			continue
+		}
+		if tasks[0].Value != tt.value || tasks[0].Rate != tt.rate {
+			t.Errorf("%s: value, rate = %v, %v, want %v, %v", tt.name, tasks[0].Value, tasks[0].Rate, tt.value, tt.rate)
+		}
+		if got := hasDiagnostic(diags, "diagAmbiguous", types.SeverityWarning); got != tt.guessed {
+			t.Errorf("%s: ambiguity warning = %v, want %v (%+v)", tt.name, got, tt.guessed, diags)
+		}
+	}
+}
+
+// hasDiagnostic reports whether diags holds a diagnostic with the given
+// catalog key and severity.
+func hasDiagnostic(diags []types.Diagnostic, key, severity string) bool {
+	for _, d := range diags {
+		if d.Key == key && d.Severity == severity {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// labeledFields maps the lower-cased labels accepted in the "Key: value" text
+// format to the Task field they fill.
+var labeledFields = map[string]string{
+	"date":      "date",
+	"workdate":  "date",
+	"work date": "date",
+	"id":        "id",
+	"itemid":    "id",
+	"item id":   "id",
+	"category":  "category",
+	"project":   "category",
+	"duration":  "duration",
+	"time":      "duration",
+	"rate":      "rate",
+	"value":     "value",
+	"payout":    "value",
+	"type":      "type",
+	"pay type":  "type",
+	"paytype":   "type",
+	"status":    "status",
+	"currency":  "currency",
+}
+
+// splitLabeledLine splits a "Key: value" line and reports which Task field the
+// key maps to. ok is false if the line is not a recognised labeled line.
+func splitLabeledLine(line string) (field, value string, ok bool) {
+	key, value, found := strings.Cut(line, ":")
+	if !found {
+		return "", "", false
+	}
+	field, ok = labeledFields[strings.ToLower(strings.TrimSpace(key))]
+	return field, strings.TrimSpace(value), ok
+}
+
+// isLabeledLine reports whether line is a "Key: value" pair with a known key.
+func isLabeledLine(line string) bool {
+	_, _, ok := splitLabeledLine(line)
+	return ok
+}
+
+// parseLabeledBlock parses a block of "Key: value" lines in any order. The block
+// ends at a blank line, an unlabeled line, or a key already seen in this block
+// (which starts the next task). lineNo is the 1-based line number of lines[0].
+func parseLabeledBlock(lines []string, lineNo int, style NumberStyle) (*types.Task, int, []types.Diagnostic) {
+	var diags []types.Diagnostic
+
+	task := &types.Task{Duration: "-"}
+	seen := make(map[string]bool)
+	consumed := 0
+
+	for ; consumed < len(lines); consumed++ {
+		line := lines[consumed]
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+		field, value, ok := splitLabeledLine(line)
+		if !ok || seen[field] {
+			break
+		}
+		seen[field] = true
+		curLine := lineNo + consumed
+
+		switch field {
+		case "date":
+			task.Date = value
+			diags = setWorkDate(diags, task, curLine, line)
+		case "id":
+			task.ID = value
+		case "category":
+			task.Category = value
+		case "status":
+			diags = setStatus(diags, task, value, curLine, line)
+		case "type":
+			taskType, known := normalizeType(value)
+			task.Type = taskType
+			if !known {
+				diags = addDiagnostic(diags, curLine, line, "type", types.SeverityWarning,
+					"diagTaskType", value)
+			}
+		case "duration":
+			if value == "" || value == "-" {
+				break
+			}
+			task.Duration = value
+			elapsed, err := ParseTime(value)
+			if err != nil {
+				diags = addDiagnostic(diags, curLine, line, "duration", types.SeverityWarning,
+					"diagDuration", err)
+			}
+			task.Elapsed = elapsed
+		case "rate":
+			if value == "" || value == "-" {
+				break
+			}
+			rate, currency, guessed, err := parseMoney(value, style)
+			if err != nil {
+				diags = addDiagnostic(diags, curLine, line, "rate", types.SeverityWarning,
+					"diagAmount", "rate", value)
+			}
+			diags = warnGuessed(diags, guessed, value, rate, "rate", curLine, line)
+			task.Rate = rate
+			diags = setCurrency(diags, task, currency, "rate", curLine, line)
+		case "value":
+			if value == "" || value == "-" {
+				break
+			}
+			val, currency, guessed, err := parseMoney(value, style)
+			if err != nil {
+				diags = addDiagnostic(diags, curLine, line, "value", types.SeverityWarning,
+					"diagAmount", "value", value)
+			}
+			diags = warnGuessed(diags, guessed, value, val, "value", curLine, line)
+			task.Value = val
+			diags = setCurrency(diags, task, currency, "value", curLine, line)
+		case "currency":
+			diags = setCurrencyField(diags, task, value, curLine, line)
+		}
+	}
+
+	// A block needs something that identifies it as a task entry
+	if !seen["id"] && !seen["value"] && !seen["duration"] {
+		for j := 0; j < consumed; j++ {
+			diags = addDiagnostic(diags, lineNo+j, lines[j], "", types.SeverityError,
+				"diagNoLabels")
+		}
+		if consumed == 0 {
+			consumed = 1
+		}
+		return nil, consumed, diags
+	}
+
+	if !seen["value"] {
+		diags = addDiagnostic(diags, lineNo, lines[0], "value", types.SeverityWarning,
+			"diagNoValueLabel")
+	}
+
+	return task, consumed, diags
+}
@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// parseAmount reads a plain decimal amount such as "2.43", "-2" or "26.5"
+// into exact cents without going through float64. Digits beyond the second
+// decimal are rounded half up.
+func parseAmount(s string) (types.Money, error) {
+	s = strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+
+	var cents int64
+	var ok bool
+	for _, r := range whole {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid amount %q", s)
+		}
+		if cents, ok = appendDigit(cents, r); !ok {
+			return 0, fmt.Errorf("amount %q is too large", s)
+		}
+	}
+	for i, r := range frac {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid amount %q", s)
+		}
+		switch {
+		case i < 2:
+			cents, ok = appendDigit(cents, r)
+		case i == 2 && r >= '5':
+			cents, ok = cents+1, cents < math.MaxInt64 // Round half up on the third decimal
+		}
+		if !ok {
+			return 0, fmt.Errorf("amount %q is too large", s)
+		}
+	}
+	for i := len(frac); i < 2; i++ {
+		if cents, ok = appendDigit(cents, '0'); !ok {
+			return 0, fmt.Errorf("amount %q is too large", s)
+		}
+	}
+
+	if negative {
+		cents = -cents
+	}
+	return types.Money(cents), nil
+}
+
+// appendDigit returns cents*10 plus the digit r, and false if that does not
+// fit in an int64.
+func appendDigit(cents int64, r rune) (int64, bool) {
+	digit := int64(r - '0')
+	if cents > (math.MaxInt64-digit)/10 {
+		return 0, false
+	}
+	return cents*10 + digit, true
+}
@@ -0,0 +1,178 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NumberStyle says which separator marks the decimals in amounts written by
+// a locale. It only settles amounts that could be read either way, such as
+// "1.234" or "1,234"; unambiguous amounts like "1.234,56" or "26,50" are
+// read the same in every style. An amount's own currency takes precedence
+// over the style the input is parsed with.
+type NumberStyle int
+
+const (
+	StyleAuto  NumberStyle = iota // "1,234" is a thousand; "1.234" is one and a bit
+	StyleDot                      // en-US: "1,234.56"
+	StyleComma                    // pt-BR and most of Europe: "1.234,56"
+)
+
+// commaCurrencies are the currencies whose amounts are usually written with a
+// decimal comma, so an amount in one of them gets StyleComma.
+var commaCurrencies = map[string]bool{"BRL": true, "EUR": true, "ARS": true}
+
+// ParseNumberStyle reads a decimal separator, "." or ",", as a NumberStyle.
+// An empty string is StyleAuto.
+func ParseNumberStyle(s string) (NumberStyle, error) {
+	switch strings.TrimSpace(s) {
+	case "":
+		return StyleAuto, nil
+	case ".":
+		return StyleDot, nil
+	case ",":
+		return StyleComma, nil
+	}
+	return StyleAuto, fmt.Errorf("decimal separator must be '.' or ',', got %q", s)
+}
+
+// styleFor returns the number style usual for amounts in currency, or
+// fallback for an amount written without one.
+func styleFor(currency string, fallback NumberStyle) NumberStyle {
+	switch {
+	case currency == "":
+		return fallback
+	case commaCurrencies[currency]:
+		return StyleComma
+	default:
+		return StyleDot
+	}
+}
+
+// normalizeNumber rewrites a number as written in a report into a plain
+// decimal that parseAmount accepts. It understands:
+//
+//   - thousands separators: "1,234.56", "1.234,56", "1 234,56", "1'234.56"
+//   - a decimal comma: "26,50"
+//   - negatives as "-2.00", "−2.00", "2.00-" or "(2.00)"
+//
+// Digit groups after a thousands separator must be three digits long, so a
+// typo like "1,23,4" is an error rather than 1234. guessed reports that the
+// number could be read either way and style decided it.
+func normalizeNumber(s string, style NumberStyle) (plain string, guessed bool, err error) {
+	original := s
+	s = strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative, s = true, strings.TrimSpace(s[1:len(s)-1])
+	}
+	switch {
+	case strings.HasPrefix(s, "-"), strings.HasPrefix(s, "\u2212"):
+		negative, s = !negative, strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(s, "-"), "\u2212"))
+	case strings.HasPrefix(s, "+"):
+		s = strings.TrimSpace(s[1:])
+	case strings.HasSuffix(s, "-"):
+		negative, s = !negative, strings.TrimSpace(s[:len(s)-1])
+	}
+	// Spaces and apostrophes only ever group digits
+	s = strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "", "'", "").Replace(s)
+	if s == "" {
+		return "", false, fmt.Errorf("invalid number %q", original)
+	}
+
+	decimal, guessed, err := decimalSeparator(s, style)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid number %q: %v", original, err)
+	}
+	whole, frac := s, ""
+	if decimal != 0 {
+		i := strings.LastIndexByte(s, decimal)
+		whole, frac = s[:i], s[i+1:]
+	}
+	if err := checkGroups(whole); err != nil {
+		return "", false, fmt.Errorf("invalid number %q: %v", original, err)
+	}
+
+	plain = strings.NewReplacer(",", "", ".", "").Replace(whole)
+	if frac != "" {
+		plain += "." + frac
+	}
+	if negative {
+		plain = "-" + plain
+	}
+	return plain, guessed, nil
+}
+
+// decimalSeparator works out which of '.' and ',' is the decimal separator in
+// s, or 0 if it has no decimals. When both appear the last one is the decimal
+// separator. A lone separator repeated, or followed by other than three
+// digits, is unambiguous; one followed by exactly three digits is settled by
+// style, and guessed is true.
+func decimalSeparator(s string, style NumberStyle) (sep byte, guessed bool, err error) {
+	lastComma := strings.LastIndexByte(s, ',')
+	lastDot := strings.LastIndexByte(s, '.')
+	switch {
+	case lastComma < 0 && lastDot < 0:
+		return 0, false, nil
+	case lastComma >= 0 && lastDot >= 0:
+		if lastDot > lastComma {
+			if strings.Count(s, ".") > 1 {
+				return 0, false, fmt.Errorf("more than one decimal point")
+			}
+			return '.', false, nil
+		}
+		if strings.Count(s, ",") > 1 {
+			return 0, false, fmt.Errorf("more than one decimal comma")
+		}
+		return ',', false, nil
+	}
+
+	sep, last := byte('.'), lastDot
+	if lastComma >= 0 {
+		sep, last = ',', lastComma
+	}
+	if strings.Count(s, string(sep)) > 1 {
+		return 0, false, nil // "1,234,567" or "1.234.567"
+	}
+	if len(s)-last-1 != 3 || s[:last] == "" || s[:last] == "0" {
+		return sep, false, nil // "26,50", "2.43", "0.5", ".125", "0,125": only decimals
+	}
+	// "1,234" or "1.234": a decimal separator only if the style says so
+	switch style {
+	case StyleDot:
+		if sep == '.' {
+			return sep, true, nil
+		}
+		return 0, true, nil
+	case StyleComma:
+		if sep == ',' {
+			return sep, true, nil
+		}
+		return 0, true, nil
+	}
+	if sep == ',' {
+		return 0, true, nil
+	}
+	return '.', true, nil
+}
+
+// checkGroups reports an error if the whole part of a number has thousands
+// separators in the wrong places.
+func checkGroups(whole string) error {
+	groups := strings.FieldsFunc(whole, func(r rune) bool { return r == ',' || r == '.' })
+	if len(groups) == 1 && groups[0] == whole {
+		return nil
+	}
+	if strings.HasPrefix(whole, ",") || strings.HasPrefix(whole, ".") || strings.HasSuffix(whole, ",") || strings.HasSuffix(whole, ".") {
+		return fmt.Errorf("misplaced separator")
+	}
+	if strings.Contains(whole, ",") && strings.Contains(whole, ".") {
+		return fmt.Errorf("mixed thousands separators")
+	}
+	for i, group := range groups {
+		if (i == 0 && (len(group) == 0 || len(group) > 3)) || (i > 0 && len(group) != 3) {
+			return fmt.Errorf("thousands groups must have three digits")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package parser
+
+import "testing"
+
+func TestNormalizeNumber(t *testing.T) {
+	tests := []struct {
+		in      string
+		style   NumberStyle
+		want    string
+		guessed bool
+		wantErr bool
+	}{
+		{in: "26.50", want: "26.50"},
+		{in: "26,50", want: "26.50"},
+		{in: "1,234.56", want: "1234.56"},
+		{in: "1.234,56", want: "1234.56"},
+		{in: "1 234,56", want: "1234.56"},
+		{in: "1\u00a0234,56", want: "1234.56"},
+		{in: "1'234.56", want: "1234.56"},
+		{in: "1,234,567", want: "1234567"},
+		{in: "1.234.567", want: "1234567"},
+		{in: "0.5", want: "0.5"},
+		{in: ".125", want: ".125"},
+		{in: "0,125", want: "0.125"},
+		{in: "-2.00", want: "-2.00"},
+		{in: "−2.00", want: "-2.00"},
+		{in: "2.00-", want: "-2.00"},
+		{in: "(2.00)", want: "-2.00"},
+		{in: "+2.00", want: "2.00"},
+		{in: "42", want: "42"},
+
+		// Could be read either way: the style decides, and the guess is reported
+		{in: "1.234", style: StyleAuto, want: "1.234", guessed: true},
+		{in: "1,234", style: StyleAuto, want: "1234", guessed: true},
+		{in: "1.234", style: StyleDot, want: "1.234", guessed: true},
+		{in: "1,234", style: StyleDot, want: "1234", guessed: true},
+		{in: "1.234", style: StyleComma, want: "1234", guessed: true},
+		{in: "1,234", style: StyleComma, want: "1.234", guessed: true},
+		{in: "26,50", style: StyleDot, want: "26.50"},
+		{in: "2.43", style: StyleComma, want: "2.43"},
+
+		{in: "", wantErr: true},
+		{in: "()", wantErr: true},
+		{in: "1,23,4", wantErr: true},
+		{in: "12,34.56", wantErr: true},
+		{in: "1.2.3,45", wantErr: true},
+		{in: "1.234.5", wantErr: true},
+		{in: "1,2,3.45.6", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, guessed, err := normalizeNumber(tt.in, tt.style)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("normalizeNumber(%q, %d) = %q, want an error", tt.in, tt.style, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("normalizeNumber(%q, %d): %v", tt.in, tt.style, err)
+			continue
+		}
+		if got != tt.want || guessed != tt.guessed {
+			t.Errorf("normalizeNumber(%q, %d) = %q, %v, want %q, %v", tt.in, tt.style, got, guessed, tt.want, tt.guessed)
+		}
+	}
+}
+
+func TestParseNumberStyle(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    NumberStyle
+		wantErr bool
+	}{
+		{in: "", want: StyleAuto},
+		{in: ".", want: StyleDot},
+		{in: ",", want: StyleComma},
+		{in: " , ", want: StyleComma},
+		{in: ";", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseNumberStyle(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseNumberStyle(%q) = %d, %v, want %d, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
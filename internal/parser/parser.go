@@ -4,81 +4,79 @@ import (
 	"encoding/csv"
 	"io"
 	"log"
-	"strconv"
 	"strings"
 
+	"github.com/erickgnclvs/go-task-viewer/internal/i18n"
 	"github.com/erickgnclvs/go-task-viewer/internal/types"
 )
 
-// ParseTime function remains the same...
-func ParseTime(timeStr string) float64 {
-	// Skip empty or placeholder values
-	if timeStr == "" || timeStr == "-" {
-		return 0
-	}
-
-	// Clean the input
-	timeStr = strings.TrimSpace(timeStr)
-
-	// Initialize total minutes
-	totalMinutes := 0.0
-
-	// Handle hours (more robustly - find 'h' first)
-	hourParts := strings.SplitN(timeStr, "h", 2)
-	if len(hourParts) == 2 {
-		hourStr := strings.TrimSpace(hourParts[0])
-		hours, err := strconv.ParseFloat(hourStr, 64)
-		if err == nil {
-			totalMinutes += hours * 60
-		}
-		timeStr = strings.TrimSpace(hourParts[1]) // Remaining part
-	}
-
-	// Handle minutes (find 'm' first)
-	minuteParts := strings.SplitN(timeStr, "m", 2)
-	if len(minuteParts) == 2 {
-		minuteStr := strings.TrimSpace(minuteParts[0])
-		minutes, err := strconv.ParseFloat(minuteStr, 64)
-		if err == nil {
-			totalMinutes += minutes
-		}
-		timeStr = strings.TrimSpace(minuteParts[1]) // Remaining part
+// csvHeaderField maps a CSV header name onto the Task field it fills, or "" if
+// the column is not recognised.
+func csvHeaderField(col string) string {
+	switch strings.ToLower(strings.TrimSpace(col)) { // Trim spaces from header
+	case "workdate", "date":
+		return "date"
+	case "itemid", "id":
+		return "id"
+	case "duration":
+		return "duration"
+	case "rateapplied", "rate":
+		return "rate"
+	case "payout", "value":
+		return "value"
+	case "paytype", "type":
+		return "type"
+	case "projectname", "project", "category":
+		return "category"
+	case "status":
+		return "status"
+	case "currency":
+		return "currency"
 	}
+	return ""
+}
 
-	// Handle seconds (find 's' first)
-	secondParts := strings.SplitN(timeStr, "s", 2)
-	if len(secondParts) == 2 {
-		secondStr := strings.TrimSpace(secondParts[0])
-		seconds, err := strconv.ParseFloat(secondStr, 64)
-		if err == nil {
-			totalMinutes += seconds / 60
-		}
-		// No remaining part needed after seconds usually
+// ParseCSV reads delimited task data and returns the parsed tasks along with
+// any diagnostics for rows that were dropped or had fields zeroed. The input
+// is decoded with DecodeInput, and the delimiter (comma, tab or semicolon) is
+// taken from the header, as spreadsheets re-save exports with whichever their
+// locale uses.
+func ParseCSV(file io.Reader) ([]types.Task, []types.Diagnostic) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, addDiagnostic(nil, 0, "", "", types.SeverityError, "diagRead", err)
 	}
+	input, _ := DecodeInput(data)
+	return ParseDelimited(strings.NewReader(input), sniffDelimiter(input, nil))
+}
 
-	// Handle cases where only numbers are present (assume minutes?) - Optional
-	// if totalMinutes == 0 && strings.TrimSpace(timeStr) != "" {
-	//     // Maybe treat raw numbers as minutes? Or log warning?
-	// }
-
-	return totalMinutes
+// ParseDelimited is ParseCSV for an arbitrary field delimiter (e.g. '\t' or ';').
+func ParseDelimited(file io.Reader, comma rune) ([]types.Task, []types.Diagnostic) {
+	return parseDelimited(file, comma, nil, StyleAuto)
 }
 
-// ParseCSV function remains the same...
-func ParseCSV(file io.Reader) []types.Task {
+// parseDelimited is ParseDelimited with the column and pay type names of
+// profile, which may be nil, reading ambiguous amounts in style.
+func parseDelimited(file io.Reader, comma rune, profile *Profile, style NumberStyle) ([]types.Task, []types.Diagnostic) {
 	var tasks []types.Task
+	var diags []types.Diagnostic
 
 	// Create CSV reader
 	reader := csv.NewReader(file)
+	reader.Comma = comma
 	reader.TrimLeadingSpace = true
 
 	// Read and skip header row
 	header, err := reader.Read()
 	if err != nil {
 		if err != io.EOF { // Allow empty CSVs
-			log.Printf("Error reading CSV header: %v\n", err)
+			diags = addDiagnostic(diags, csvErrorLine(err, 1), "", "", types.SeverityError,
+				"diagHeader", err)
 		}
-		return tasks
+		return tasks, diags
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff") // A BOM would hide the first column name
 	}
 
 	// Map CSV columns to our expected structure
@@ -90,28 +88,36 @@ func ParseCSV(file io.Reader) []types.Task {
 	typeIdx := -1
 	projectIdx := -1
 	statusIdx := -1
+	currencyIdx := -1
 
 	for i, col := range header {
-		switch strings.ToLower(strings.TrimSpace(col)) { // Trim spaces from header
-		case "workdate", "date":
+		switch profile.field(col) {
+		case "date":
 			dateIdx = i
-		case "itemid", "id":
+		case "id":
 			idIdx = i
 		case "duration":
 			durationIdx = i
-		case "rateapplied", "rate":
+		case "rate":
 			rateIdx = i
-		case "payout", "value":
+		case "value":
 			valueIdx = i
-		case "paytype", "type":
+		case "type":
 			typeIdx = i
-		case "projectname", "project", "category":
+		case "category":
 			projectIdx = i
 		case "status":
 			statusIdx = i
+		case "currency":
+			currencyIdx = i
 		}
 	}
 
+	if valueIdx == -1 {
+		diags = addDiagnostic(diags, 1, strings.Join(header, string(comma)), "value", types.SeverityWarning,
+			"diagNoValueCol")
+	}
+
 	// Read all records and convert to tasks
 	for {
 		record, err := reader.Read()
@@ -119,10 +125,13 @@ func ParseCSV(file io.Reader) []types.Task {
 			break
 		}
 		if err != nil {
-			log.Printf("Error reading CSV row: %v\n", err)
+			diags = addDiagnostic(diags, csvErrorLine(err, 0), strings.Join(record, string(comma)), "", types.SeverityError,
+				"diagRowDropped", err)
 			continue
 		}
 
+		line, _ := reader.FieldPos(0)
+		raw := strings.Join(record, string(comma))
 		task := types.Task{}
 
 		// Extract data from CSV columns safely
@@ -137,26 +146,42 @@ func ParseCSV(file io.Reader) []types.Task {
 		if durationIdx >= 0 && durationIdx < len(record) {
 			task.Duration = strings.Trim(record[durationIdx], " \"")
 			if task.Duration != "-" && task.Duration != "" {
-				task.DurationMins = ParseTime(task.Duration)
+				elapsed, err := ParseTime(task.Duration)
+				if err != nil {
+					diags = addDiagnostic(diags, line, raw, "duration", types.SeverityWarning,
+						"diagDuration", err)
+				}
+				task.Elapsed = elapsed
 			} else {
 				task.Duration = "-" // Standardize empty values
-				task.DurationMins = 0
+				task.Elapsed = 0
 			}
 		} else {
 			task.Duration = "-" // Ensure default if column missing
-			task.DurationMins = 0
+			task.Elapsed = 0
+		}
+
+		// An explicit currency column applies to amounts written without a symbol
+		if currencyIdx >= 0 && currencyIdx < len(record) {
+			diags = setCurrencyField(diags, &task, strings.Trim(record[currencyIdx], " \""), line, raw)
 		}
 
 		if rateIdx >= 0 && rateIdx < len(record) {
 			rateStr := strings.Trim(record[rateIdx], " \"")
 			if rateStr == "-" || rateStr == "" {
 				task.Rate = 0
-			} else if strings.Contains(rateStr, "$") && strings.Contains(rateStr, "/hr") {
-				rateVal := strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(rateStr, "$"), "/hr"))
-				task.Rate, _ = strconv.ParseFloat(rateVal, 64)
-			} else if strings.HasPrefix(rateStr, "$") { // Handle rate given just as $ amount (assume per hour?)
-				rateVal := strings.TrimSpace(strings.TrimPrefix(rateStr, "$"))
-				task.Rate, _ = strconv.ParseFloat(rateVal, 64)
+			} else {
+				// "$X.XX/hr", "R$ X,XX", "X.XX EUR" or a plain number, as written by the CSV export
+				rate, currency, guessed, err := parseMoney(rateStr, style)
+				if err == nil {
+					task.Rate = rate
+					diags = warnGuessed(diags, guessed, rateStr, rate, "rate", line, raw)
+					diags = setCurrency(diags, &task, currency, "rate", line, raw)
+				} else {
+					task.Rate = 0
+					diags = addDiagnostic(diags, line, raw, "rate", types.SeverityWarning,
+						"diagAmount", "rate", rateStr)
+				}
 			}
 		}
 
@@ -165,43 +190,28 @@ func ParseCSV(file io.Reader) []types.Task {
 			// Allow for "-" or empty value string
 			if valueStr == "-" || valueStr == "" {
 				task.Value = 0
-			} else if strings.HasPrefix(valueStr, "$") {
-				valueVal := strings.TrimSpace(strings.TrimPrefix(valueStr, "$"))
-				// Use MustParseFloat or check error
-				val, err := strconv.ParseFloat(valueVal, 64)
-				if err == nil {
-					task.Value = val
-				} else {
-					log.Printf("Warning: Could not parse value '%s' from CSV row: %v", valueStr, record)
-					task.Value = 0 // Default to 0 on parse error
-				}
 			} else {
-				// Handle case where value might be a plain number without '$'
-				val, err := strconv.ParseFloat(strings.TrimSpace(valueStr), 64)
+				// Accept an amount with or without a currency symbol or code
+				val, currency, guessed, err := parseMoney(valueStr, style)
 				if err == nil {
 					task.Value = val
+					diags = warnGuessed(diags, guessed, valueStr, val, "value", line, raw)
+					diags = setCurrency(diags, &task, currency, "value", line, raw)
 				} else {
-					log.Printf("Warning: Could not parse value '%s' (no '$' prefix) from CSV row: %v", valueStr, record)
 					task.Value = 0 // Default to 0 on parse error
+					diags = addDiagnostic(diags, line, raw, "value", types.SeverityWarning,
+						"diagAmount", "value", valueStr)
 				}
 			}
 		}
 
 		if typeIdx >= 0 && typeIdx < len(record) {
 			payType := strings.Trim(record[typeIdx], " \"")
-			switch strings.ToLower(payType) {
-			case "prepay", "regularpay", "task": // Added aliases
-				task.Type = "Task"
-			case "overtimepay", "exceeded time": // Added alias
-				task.Type = "Exceeded Time"
-			case "missionreward":
-				task.Type = "Mission Reward"
-			case "qaoperation", "operation":
-				task.Type = "Operation"
-			case "adjustment":
-				task.Type = "Adjustment" // Handle Adjustment type
-			default:
-				task.Type = payType // Keep original if unknown
+			taskType, known := profile.payType(payType)
+			task.Type = taskType
+			if !known {
+				diags = addDiagnostic(diags, line, raw, "type", types.SeverityWarning,
+					"diagPayType", payType)
 			}
 		}
 
@@ -210,27 +220,38 @@ func ParseCSV(file io.Reader) []types.Task {
 		}
 
 		if statusIdx >= 0 && statusIdx < len(record) {
-			task.Status = strings.Trim(record[statusIdx], " \"")
+			diags = setStatus(diags, &task, strings.Trim(record[statusIdx], " \""), line, raw)
 		}
 
+		diags = setWorkDate(diags, &task, line, raw)
+
 		// Debug output
-		// log.Printf("CSV Parsed: Date=%s, ID=%s, Type=%s, Duration=%s, Rate=%.2f, Value=%.2f, DurationMins=%.2f\n",
-		// 	task.Date, task.ID, task.Type, task.Duration, task.Rate, task.Value, task.DurationMins)
+		// log.Printf("CSV Parsed: Date=%s, ID=%s, Type=%s, Duration=%s, Rate=%.2f, Value=%.2f, Elapsed=%s\n",
+		// 	task.Date, task.ID, task.Type, task.Duration, task.Rate, task.Value, task.Elapsed)
 
 		tasks = append(tasks, task)
 	}
 
-	log.Printf("Total de %d tarefas foram analisadas do CSV.\n", len(tasks))
-	return tasks
+	log.Printf("Parsed %d tasks from CSV (delimiter %q).\n", len(tasks), comma)
+	return tasks, diags
 }
 
-// ParseText function remains the same...
-func ParseText(input string) []types.Task {
+// ParseText reads pasted multi-line task blocks and returns the parsed tasks
+// along with diagnostics for lines that were skipped or fields that were zeroed.
+func ParseText(input string) ([]types.Task, []types.Diagnostic) {
+	return parseText(input, StyleAuto)
+}
+
+// parseText is ParseText reading ambiguous amounts in style.
+func parseText(input string, style NumberStyle) ([]types.Task, []types.Diagnostic) {
 	var tasks []types.Task
-	log.Printf("[DEBUG] Iniciando ParseText com %d caracteres de texto", len(input))
+	var diags []types.Diagnostic
+	log.Printf("[DEBUG] "+i18n.English.T("logParseText"), len(input))
 	lines := strings.Split(input, "\n")
-	log.Printf("[DEBUG] Dividido em %d linhas", len(lines))
+	log.Printf("[DEBUG] "+i18n.English.T("logSplitLines"), len(lines))
 
+	// Lines that start no block are collected into runs, each reported once
+	var skipped []int
 	for i := 0; i < len(lines); {
 		// Skip empty lines that might separate task blocks
 		if strings.TrimSpace(lines[i]) == "" {
@@ -238,79 +259,144 @@ func ParseText(input string) []types.Task {
 			continue
 		}
 
-		task, advance := parseTextBlock(lines[i:])
+		// Pick the layout per block: labeled "Key: value" lines or the positional 8-line block
+		var task *types.Task
+		var advance int
+		var blockDiags []types.Diagnostic
+		if isLabeledLine(lines[i]) {
+			task, advance, blockDiags = parseLabeledBlock(lines[i:], i+1, style)
+		} else {
+			task, advance, blockDiags = parseTextBlock(lines[i:], i+1, style)
+		}
+		if task == nil && advance == 1 && len(blockDiags) == 0 {
+			skipped = append(skipped, i)
+			i++
+			continue
+		}
+		diags = addSkippedLines(diags, lines, skipped)
+		skipped = nil
+		diags = append(diags, blockDiags...)
 		if task != nil {
 			// log.Printf("[DEBUG] Tarefa (text) encontrada: Type=%s, Value=%.2f", task.Type, task.Value) // Log value here too
 			tasks = append(tasks, *task)
 		}
-		// If parseTextBlock returns nil, it means it wasn't a valid block starting at lines[i].
+		// If the block parser returns nil, it means it wasn't a valid block starting at lines[i].
 		// We should advance by 1 to check the next line as a potential start.
-		// If it *did* parse a block, advance is 8. If it determined it wasn't a block
-		// at the start, advance should be 1.
+		// If it *did* parse a block, advance is the number of lines in it (8 for the
+		// positional layout). If it determined it wasn't a block at the start, advance should be 1.
 		if advance == 0 { // Prevent infinite loops if parseTextBlock has a bug
 			log.Printf("[WARN] parseTextBlock returned advance=0, advancing by 1 to avoid loop. Line: %s", lines[i])
 			advance = 1
 		}
 		i += advance // Advance by the number of lines consumed or skipped
 	}
+	diags = addSkippedLines(diags, lines, skipped)
 
-	log.Printf("Total de %d tarefas foram analisadas do texto.\n", len(tasks))
-	return tasks
+	log.Printf("Parsed %d tasks from text.\n", len(tasks))
+	return tasks, diags
 }
 
-// **REVISED parseTextBlock**
-func parseTextBlock(lines []string) (*types.Task, int) {
-	// Need at least 8 lines for a potential task block
-	if len(lines) < 8 {
-		// log.Printf("[DEBUG] Not enough lines remaining (%d) for a text block.", len(lines))
-		return nil, len(lines) // Consumed all remaining lines
+// addSkippedLines reports a run of lines that start no task block, given by
+// their indexes in lines, as one diagnostic. A run with a duration or an amount
+// in a currency is probably a broken task block and is an error; anything else,
+// such as a title, is a warning.
+func addSkippedLines(diags []types.Diagnostic, lines []string, skipped []int) []types.Diagnostic {
+	if len(skipped) == 0 {
+		return diags
+	}
+	raw := make([]string, len(skipped))
+	severity := types.SeverityWarning
+	for i, idx := range skipped {
+		raw[i] = lines[idx]
+		if looksLikeTaskLine(lines[idx]) {
+			severity = types.SeverityError
+		}
+	}
+	first, last := skipped[0]+1, skipped[len(skipped)-1]+1
+	key := "diagNoBlock"
+	if severity == types.SeverityError {
+		key = "diagIncomplete"
+	}
+	if first == last {
+		return addDiagnostic(diags, first, raw[0], "", severity, key)
 	}
+	return addDiagnostic(diags, first, strings.Join(raw, "\n"), "", severity, key+"s", first, last)
+}
+
+// looksLikeTaskLine reports whether line holds what only a task line would: a
+// work date, a duration, or an amount in a currency.
+func looksLikeTaskLine(line string) bool {
+	if _, err := ParseDate(line); err == nil {
+		return true
+	}
+	if d, err := ParseTime(line); err == nil && d > 0 {
+		return true
+	}
+	for _, field := range strings.Fields(line) {
+		if _, code, _, err := parseMoney(field, StyleAuto); err == nil && code != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// **REVISED parseTextBlock**
+// lineNo is the 1-based line number of lines[0] in the original input.
+func parseTextBlock(lines []string, lineNo int, style NumberStyle) (*types.Task, int, []types.Diagnostic) {
+	var diags []types.Diagnostic
 
-	// Check for the expected structure: non-empty lines 0, 1, 2, 4, 5, 7 and empty lines 3, 6
-	if strings.TrimSpace(lines[0]) == "" || strings.TrimSpace(lines[1]) == "" ||
-		strings.TrimSpace(lines[2]) == "" || strings.TrimSpace(lines[4]) == "" || // Line 4 must have *something*
-		strings.TrimSpace(lines[5]) == "" || strings.TrimSpace(lines[7]) == "" ||
-		strings.TrimSpace(lines[3]) != "" || strings.TrimSpace(lines[6]) != "" {
+	// Not a task block, or too few lines left for one: skip only this line, so
+	// that blocks further down still get parsed. The caller reports it.
+	if !hasTextBlockShape(lines) {
 		// log.Printf("[DEBUG] Line structure mismatch at line starting with: %s", lines[0])
-		return nil, 1 // Not a task block, advance by 1 line and try again
+		return nil, 1, nil
+	}
+
+	typeLine := strings.TrimSpace(lines[5])
+	taskType, known := normalizeType(typeLine)
+	if !known {
+		diags = addDiagnostic(diags, lineNo+5, lines[5], "type", types.SeverityWarning,
+			"diagTaskType", typeLine)
 	}
 
 	task := &types.Task{
 		Date:     strings.TrimSpace(lines[0]),
 		ID:       strings.TrimSpace(lines[1]),
 		Category: strings.TrimSpace(lines[2]),
-		Status:   strings.TrimSpace(lines[7]),
 		// Assign Type based on line 5, handle variations
-		Type:         mapTextType(strings.TrimSpace(lines[5])),
-		Duration:     "-", // Default
-		Rate:         0.0,
-		Value:        0.0,
-		DurationMins: 0.0,
+		Type:     taskType,
+		Duration: "-", // Default
+		Rate:     0,
+		Value:    0,
+		Elapsed:  0,
 	}
 
 	// --- Robust Parsing of Line 4 ---
 	durationRateValue := strings.TrimSpace(lines[4])
-	parts := strings.Fields(durationRateValue)
+	drvLine := lineNo + 4
+	parts := joinCurrencyTokens(strings.Fields(durationRateValue))
 	nParts := len(parts)
 
 	valueIdx := -1
 	rateIdx := -1
 	durationEndIdx := nParts // Assume all parts are duration initially
 
-	// 1. Find Value (last part starting with '$', not containing '/hr')
-	if nParts > 0 && strings.HasPrefix(parts[nParts-1], "$") && !strings.Contains(parts[nParts-1], "/hr") {
-		valueStr := strings.TrimPrefix(parts[nParts-1], "$")
-		val, err := strconv.ParseFloat(valueStr, 64)
+	// 1. Find Value (last part with a currency symbol or code, not a per-hour rate)
+	if nParts > 0 && hasCurrency(parts[nParts-1]) && !isRateToken(parts[nParts-1]) {
+		val, currency, guessed, err := parseMoney(parts[nParts-1], style)
 		if err == nil {
 			task.Value = val
+			diags = warnGuessed(diags, guessed, parts[nParts-1], val, "value", drvLine, lines[4])
 			valueIdx = nParts - 1
 			durationEndIdx = valueIdx // Duration ends before value
+			diags = setCurrency(diags, task, currency, "value", drvLine, lines[4])
 		} else {
-			log.Printf("[WARN] Text Parser: Failed to parse potential value '%s': %v", parts[nParts-1], err)
+			diags = addDiagnostic(diags, drvLine, lines[4], "value", types.SeverityWarning,
+				"diagAmount", "value", parts[nParts-1])
 		}
 	}
 
-	// 2. Find Rate (part before Value OR last part, containing '$/hr')
+	// 2. Find Rate (part before Value OR last part, an amount with a currency)
 	rateSearchIdx := -1
 	if valueIdx > 0 {
 		rateSearchIdx = valueIdx - 1 // Look before value
@@ -318,30 +404,27 @@ func parseTextBlock(lines []string) (*types.Task, int) {
 		rateSearchIdx = nParts - 1
 	}
 
-	if rateSearchIdx >= 0 && strings.Contains(parts[rateSearchIdx], "$") && strings.Contains(parts[rateSearchIdx], "/hr") {
-		rateStr := strings.TrimSuffix(strings.TrimPrefix(parts[rateSearchIdx], "$"), "/hr")
-		rate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
+	// Handles "$26.50/hr" as well as "$7.95 $0.00" where the rate has no /hr
+	if rateSearchIdx >= 0 && hasCurrency(parts[rateSearchIdx]) &&
+		(isRateToken(parts[rateSearchIdx]) || valueIdx != rateSearchIdx) {
+		rate, currency, guessed, err := parseMoney(parts[rateSearchIdx], style)
 		if err == nil {
 			task.Rate = rate
+			diags = warnGuessed(diags, guessed, parts[rateSearchIdx], rate, "rate", drvLine, lines[4])
 			rateIdx = rateSearchIdx
 			durationEndIdx = rateIdx // Duration ends before rate
+			diags = setCurrency(diags, task, currency, "rate", drvLine, lines[4])
 		} else {
-			log.Printf("[WARN] Text Parser: Failed to parse potential rate '%s': %v", parts[rateSearchIdx], err)
-		}
-	} else if rateSearchIdx >= 0 && strings.HasPrefix(parts[rateSearchIdx], "$") && valueIdx != rateSearchIdx {
-		// Handle case like "$7.95 $0.00" where rate doesn't have /hr
-		// Check if it looks like a rate (starts with $) and wasn't already identified as value
-		rateStr := strings.TrimPrefix(parts[rateSearchIdx], "$")
-		rate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
-		if err == nil {
-			task.Rate = rate
-			rateIdx = rateSearchIdx
-			durationEndIdx = rateIdx // Duration ends before rate
-		} else {
-			log.Printf("[WARN] Text Parser: Failed to parse potential rate (no /hr) '%s': %v", parts[rateSearchIdx], err)
+			diags = addDiagnostic(diags, drvLine, lines[4], "rate", types.SeverityWarning,
+				"diagAmount", "rate", parts[rateSearchIdx])
 		}
 	}
 
+	if valueIdx == -1 {
+		diags = addDiagnostic(diags, drvLine, lines[4], "value", types.SeverityWarning,
+			"diagNoValue")
+	}
+
 	// 3. Extract Duration (parts before rate/value)
 	if durationEndIdx > 0 {
 		durationParts := parts[0:durationEndIdx]
@@ -366,42 +449,64 @@ func parseTextBlock(lines []string) (*types.Task, int) {
 	}
 
 	// Ensure duration is "-" if it still looks like a money value mistakenly
-	if strings.HasPrefix(task.Duration, "$") {
-		log.Printf("[WARN] Text Parser: Corrected Duration from '%s' to '-'", task.Duration)
+	if hasCurrency(task.Duration) {
+		diags = addDiagnostic(diags, drvLine, lines[4], "duration", types.SeverityWarning,
+			"diagMoneyDuration", task.Duration)
 		task.Duration = "-"
 	}
 
-	// Calculate duration minutes *after* parsing duration string
+	// Calculate elapsed time *after* parsing duration string
 	if task.Duration != "" && task.Duration != "-" {
-		task.DurationMins = ParseTime(task.Duration)
+		elapsed, err := ParseTime(task.Duration)
+		if err != nil {
+			diags = addDiagnostic(diags, drvLine, lines[4], "duration", types.SeverityWarning,
+				"diagDuration", err)
+		}
+		task.Elapsed = elapsed
 	} else {
-		task.Duration = "-"   // Standardize
-		task.DurationMins = 0 // Ensure it's 0 if duration is missing/placeholder
+		task.Duration = "-" // Standardize
+		task.Elapsed = 0    // Ensure it's 0 if duration is missing/placeholder
 	}
 
+	diags = setWorkDate(diags, task, lineNo, lines[0])
+	diags = setStatus(diags, task, lines[7], lineNo+7, lines[7])
+
 	// Debug output (optional)
 	// log.Printf("Text Parsed: Date=%s, ID=%s, Type=%s, Category=%s, Status=%s", task.Date, task.ID, task.Type, task.Category, task.Status)
-	// log.Printf("           Line 4: '%s' -> Duration='%s', Rate=%.2f, Value=%.2f, DurationMins=%.2f", durationRateValue, task.Duration, task.Rate, task.Value, task.DurationMins)
+	// log.Printf("           Line 4: '%s' -> Duration='%s', Rate=%.2f, Value=%.2f, Elapsed=%s", durationRateValue, task.Duration, task.Rate, task.Value, task.Elapsed)
 
-	return task, 8 // Successfully parsed a task block of 8 lines
+	return task, 8, diags // Successfully parsed a task block of 8 lines
+}
+
+// hasTextBlockShape reports whether lines starts with the positional 8-line layout:
+// non-empty lines 0, 1, 2, 4, 5, 7 and empty lines 3, 6.
+func hasTextBlockShape(lines []string) bool {
+	if len(lines) < 8 {
+		return false
+	}
+	return strings.TrimSpace(lines[0]) != "" && strings.TrimSpace(lines[1]) != "" &&
+		strings.TrimSpace(lines[2]) != "" && strings.TrimSpace(lines[4]) != "" && // Line 4 must have *something*
+		strings.TrimSpace(lines[5]) != "" && strings.TrimSpace(lines[7]) != "" &&
+		strings.TrimSpace(lines[3]) == "" && strings.TrimSpace(lines[6]) == ""
 }
 
-// Helper to map text input types to standardized types
-func mapTextType(textType string) string {
-	switch strings.ToLower(textType) {
-	case "task", "regular pay", "prepay":
-		return "Task"
-	case "exceeded time", "overtime pay":
-		return "Exceeded Time"
-	case "mission reward":
-		return "Mission Reward"
-	case "operation", "qa operation":
-		return "Operation"
+// normalizeType maps the pay-type spellings used by the platform's CSV export,
+// the pasted text layouts and JSON onto the standardized task types.
+// The second return value is false when the type is not recognised.
+func normalizeType(payType string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(payType)) {
+	case "task", "prepay", "regularpay", "regular pay":
+		return "Task", true
+	case "exceeded time", "overtimepay", "overtime pay":
+		return "Exceeded Time", true
+	case "missionreward", "mission reward":
+		return "Mission Reward", true
+	case "operation", "qaoperation", "qa operation":
+		return "Operation", true
 	case "adjustment":
-		return "Adjustment"
+		return "Adjustment", true
 	default:
-		log.Printf("[WARN] Unknown text task type encountered: %s", textType)
-		return textType // Keep original if unknown
+		return payType, false // Keep original if unknown
 	}
 }
 
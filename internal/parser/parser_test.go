@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+const textBlock = "Mar 30, 2025\n67e78d4f24eaa8f13ae8a7d1\nhopper_v2\n\n5m 30s $26.50/hr $2.43\nTask\n\npending\n"
+
+func TestParseTextSkippedLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		tasks    int
+		key      string // Key of the single expected diagnostic, "" for none
+		severity string
+		line     int
+		args     []string
+	}{
+		{name: "clean block", input: textBlock, tasks: 1},
+		{name: "leading title", input: "My tasks\n\n" + textBlock, tasks: 1,
+			key: "diagNoBlock", severity: types.SeverityWarning, line: 1},
+		{name: "leading title before labeled block", input: "My tasks\nID: a\nValue: $2.00\n", tasks: 1,
+			key: "diagNoBlock", severity: types.SeverityWarning, line: 1},
+		{name: "trailing junk", input: textBlock + "\nthanks\nsee you\n\nbye\n", tasks: 1,
+			key: "diagNoBlocks", severity: types.SeverityWarning, line: 10, args: []string{"10", "13"}},
+		{name: "trailing incomplete block", input: textBlock + "\nApr 1, 2025\nabc123\nhopper_v2\n\n10m $30.00/hr $5.00\n", tasks: 1,
+			key: "diagIncompletes", severity: types.SeverityError, line: 10, args: []string{"10", "14"}},
+		{name: "junk between blocks", input: textBlock + "note\n" + textBlock, tasks: 2,
+			key: "diagNoBlock", severity: types.SeverityWarning, line: 9},
+	}
+	for _, tt := range tests {
+		tasks, diags := ParseText(tt.input)
+		if len(tasks) != tt.tasks {
+			t.Errorf("%s: ParseText returned %d tasks, want %d", tt.name, len(tasks), tt.tasks)
+		}
+		if tt.key == "" {
+			if len(diags) != 0 {
+				t.Errorf("%s: ParseText diagnostics = %+v, want none", tt.name, diags)
+			}
+			continue
+		}
+		if len(diags) != 1 {
+			t.Errorf("%s: ParseText diagnostics = %+v, want one %s", tt.name, diags, tt.key)
+			continue
+		}
+		d := diags[0]
+		if d.Key != tt.key || d.Severity != tt.severity || d.Line != tt.line ||
+			strings.Join(d.Args, ",") != strings.Join(tt.args, ",") {
+			t.Errorf("%s: ParseText diagnostic = %+v, want %s %s at line %d with args %v",
+				tt.name, d, tt.severity, tt.key, tt.line, tt.args)
+		}
+	}
+}
+
+func TestParseDelimitedNoValueColumn(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		comma rune
+		raw   string
+	}{
+		{name: "csv", input: "date,id\nMar 30 2025,a\n", comma: ',', raw: "date,id"},
+		{name: "tsv", input: "date\tid\nMar 30 2025\ta\n", comma: '\t', raw: "date\tid"},
+		{name: "semicolon", input: "date;id\nMar 30 2025;a\n", comma: ';', raw: "date;id"},
+	}
+	for _, tt := range tests {
+		_, diags := ParseDelimited(strings.NewReader(tt.input), tt.comma)
+		var found bool
+		for _, d := range diags {
+			if d.Key == "diagNoValueCol" {
+				found = true
+				if d.Raw != tt.raw {
+					t.Errorf("%s: diagNoValueCol raw = %q, want %q", tt.name, d.Raw, tt.raw)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s: ParseDelimited diagnostics = %+v, want diagNoValueCol", tt.name, diags)
+		}
+	}
+}
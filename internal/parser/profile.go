@@ -0,0 +1,165 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// Profile maps the columns and pay types of another platform's delimited
+// export onto task fields and types. Names a profile does not list fall back
+// to the built-in ones, so it only needs the ones that differ. A nil *Profile
+// is valid and uses the built-in names only.
+type Profile struct {
+	Name    string            `json:"name"`    // Shown in the upload form; defaults to the file name
+	Columns map[string]string `json:"columns"` // Header name -> field, e.g. "Earnings": "value"
+	Types   map[string]string `json:"types"`   // Pay type -> task type, e.g. "Bonus": "Mission Reward"
+}
+
+// Profiles is a set of mapping profiles, sorted by name.
+type Profiles []*Profile
+
+// LoadProfile reads a mapping profile from a JSON file.
+func LoadProfile(path string) (*Profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("mapping profile: %w", err)
+	}
+	defer f.Close()
+
+	p, err := readProfile(f)
+	if err != nil {
+		return nil, fmt.Errorf("mapping profile %s: %w", path, err)
+	}
+	if p.Name == "" {
+		p.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return p, nil
+}
+
+// LoadProfiles reads every .json file in dir as a mapping profile. Two
+// profiles with the same name are an error.
+func LoadProfiles(dir string) (Profiles, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("mapping profiles: %w", err)
+	}
+	var profiles Profiles
+	for _, path := range paths {
+		p, err := LoadProfile(path)
+		if err != nil {
+			return nil, err
+		}
+		if profiles.Lookup(p.Name) != nil {
+			return nil, fmt.Errorf("mapping profile %s: name %q is already used by another profile", path, p.Name)
+		}
+		profiles = append(profiles, p)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles, nil
+}
+
+// readProfile decodes a profile and checks its mappings. Header names and pay
+// types are matched without regard to case or surrounding spaces. Targets may
+// be given as any name the built-in parser accepts, e.g. "payout" for "value"
+// or "prepay" for "Task", and are stored normalized.
+func readProfile(r io.Reader) (*Profile, error) {
+	var p Profile
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&p); err != nil {
+		return nil, err
+	}
+	p.Name = strings.TrimSpace(p.Name)
+
+	columns := make(map[string]string, len(p.Columns))
+	for header, target := range p.Columns {
+		field := csvHeaderField(target)
+		if field == "" {
+			return nil, fmt.Errorf("column %q: unknown field %q (use date, id, duration, rate, value, type, category, status or currency)", header, target)
+		}
+		columns[strings.ToLower(strings.TrimSpace(header))] = field
+	}
+	taskTypes := make(map[string]string, len(p.Types))
+	for payType, target := range p.Types {
+		taskType, known := normalizeType(target)
+		if !known {
+			return nil, fmt.Errorf("pay type %q: unknown task type %q (use Task, Exceeded Time, Mission Reward, Operation or Adjustment)", payType, target)
+		}
+		taskTypes[strings.ToLower(strings.TrimSpace(payType))] = taskType
+	}
+	p.Columns, p.Types = columns, taskTypes
+	return &p, nil
+}
+
+// Lookup returns the profile called name, ignoring case, or nil.
+func (ps Profiles) Lookup(name string) *Profile {
+	for _, p := range ps {
+		if strings.EqualFold(p.Name, strings.TrimSpace(name)) {
+			return p
+		}
+	}
+	return nil
+}
+
+// Names returns the profile names in order.
+func (ps Profiles) Names() []string {
+	names := make([]string, len(ps))
+	for i, p := range ps {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// field maps a header name onto the Task field it fills, or "" if the column
+// is not recognised.
+func (p *Profile) field(col string) string {
+	if p != nil {
+		if field, ok := p.Columns[strings.ToLower(strings.TrimSpace(col))]; ok {
+			return field
+		}
+	}
+	return csvHeaderField(col)
+}
+
+// payType maps a pay type onto a standardized task type, like normalizeType.
+func (p *Profile) payType(payType string) (string, bool) {
+	if p != nil {
+		if taskType, ok := p.Types[strings.ToLower(strings.TrimSpace(payType))]; ok {
+			return taskType, true
+		}
+	}
+	return normalizeType(payType)
+}
+
+// DetectFormat is the package DetectFormat, counting the profile's header
+// names as known columns.
+func (p *Profile) DetectFormat(input string) Detection {
+	return detectFormat(input, p)
+}
+
+// Parse is the package Parse, reading delimited input with the profile's
+// column and pay type names, and amounts that could be read either way, such
+// as "1.234" without a currency, in style. Other formats are parsed as usual.
+func (p *Profile) Parse(format, input string, style NumberStyle) ([]types.Task, []types.Diagnostic) {
+	switch format {
+	case FormatCSV:
+		// "csv" is what the API and the form send for any spreadsheet export, so
+		// the delimiter is taken from the header rather than assumed
+		return parseDelimited(strings.NewReader(input), sniffDelimiter(input, p), p, style)
+	case FormatTSV:
+		return parseDelimited(strings.NewReader(input), '\t', p, style)
+	case FormatSemicolon:
+		return parseDelimited(strings.NewReader(input), ';', p, style)
+	case FormatJSON:
+		return parseJSON(strings.NewReader(input), style)
+	default: // FormatText and FormatLabeled share a parser that picks the layout per block
+		return parseText(input, style)
+	}
+}
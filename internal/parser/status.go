@@ -0,0 +1,38 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// normalizeStatus maps the status spellings seen in platform exports onto the
+// known set of statuses. Empty statuses stay empty and count as pending.
+// The second return value is false when the status is not recognised.
+func normalizeStatus(status string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "":
+		return "", true
+	case "pending", "in review", "under review", "submitted", "processing", "awaiting approval":
+		return types.StatusPending, true
+	case "approved", "accepted", "validated", "completed", "confirmed":
+		return types.StatusApproved, true
+	case "paid", "paid out", "settled", "transferred":
+		return types.StatusPaid, true
+	case "rejected", "declined", "denied", "invalid", "cancelled", "canceled":
+		return types.StatusRejected, true
+	default:
+		return strings.TrimSpace(status), false // Keep original if unknown
+	}
+}
+
+// setStatus normalizes status onto task, reporting statuses that are not recognised.
+func setStatus(diags []types.Diagnostic, task *types.Task, status string, line int, raw string) []types.Diagnostic {
+	normalized, known := normalizeStatus(status)
+	task.Status = normalized
+	if !known {
+		diags = addDiagnostic(diags, line, raw, "status", types.SeverityWarning,
+			"diagStatus", normalized)
+	}
+	return diags
+}
@@ -0,0 +1,115 @@
+package store
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// ErrNotFound is returned by Results.Load for an unknown ID.
+var ErrNotFound = errors.New("result not found")
+
+// resultIDPattern matches the IDs generated by Results.Save, and nothing that
+// could escape the results directory.
+var resultIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{8,32}$`)
+
+// Results keeps saved analyses for permalinks, one JSON file per result.
+type Results struct {
+	dir string
+}
+
+// SavedResult is a parsed upload saved for later re-rendering. The summary is
+// not stored, it is recomputed from the tasks when the result is loaded.
+type SavedResult struct {
+	ID          string             `json:"id"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	Format      string             `json:"format"`
+	Confidence  float64            `json:"confidence"`
+	Reason      string             `json:"reason"`
+	ReasonKey   string             `json:"reasonKey,omitempty"`
+	ReasonArgs  []string           `json:"reasonArgs,omitempty"`
+	Tasks       []types.Task       `json:"tasks"`
+	Diagnostics []types.Diagnostic `json:"diagnostics"`
+}
+
+// OpenResults uses dir for saved results. The directory is created by the
+// first Save, so that a server started where it cannot write still runs and
+// only fails to share results; a file in its place is an error.
+func OpenResults(dir string) (*Results, error) {
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		return nil, fmt.Errorf("results directory %s is not a directory", dir)
+	}
+	return &Results{dir: dir}, nil
+}
+
+// Save assigns the result a new random ID, writes it and returns the ID.
+func (r *Results) Save(result SavedResult) (string, error) {
+	id, err := newResultID()
+	if err != nil {
+		return "", err
+	}
+	result.ID = id
+	result.CreatedAt = time.Now().UTC()
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("encoding result: %w", err)
+	}
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return "", fmt.Errorf("creating results directory: %w", err)
+	}
+	// O_EXCL so a (very unlikely) ID collision never overwrites another result
+	f, err := os.OpenFile(r.path(id), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("saving result: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return "", fmt.Errorf("saving result: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("saving result: %w", err)
+	}
+	return id, nil
+}
+
+// Load reads a saved result by ID.
+func (r *Results) Load(id string) (SavedResult, error) {
+	var result SavedResult
+	if !resultIDPattern.MatchString(id) {
+		return result, ErrNotFound
+	}
+	data, err := os.ReadFile(r.path(id))
+	if errors.Is(err, fs.ErrNotExist) {
+		return result, ErrNotFound
+	}
+	if err != nil {
+		return result, fmt.Errorf("reading result %s: %w", id, err)
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("decoding result %s: %w", id, err)
+	}
+	return result, nil
+}
+
+func (r *Results) path(id string) string {
+	return filepath.Join(r.dir, id+".json")
+}
+
+// newResultID returns a random, URL-safe 10-character ID.
+func newResultID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating result ID: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)[:10], nil
+}
@@ -0,0 +1,188 @@
+package store
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/erickgnclvs/go-task-viewer/internal/types"
+)
+
+// Store is an embedded, file-backed task history. The whole history is kept in
+// memory and rewritten atomically to a single JSON file on every import.
+type Store struct {
+	path string
+
+	mu      sync.RWMutex
+	records map[string]record
+}
+
+// record is one stored task plus bookkeeping.
+type record struct {
+	Task      types.Task `json:"task"`
+	FirstSeen time.Time  `json:"firstSeen"`
+	LastSeen  time.Time  `json:"lastSeen"`
+}
+
+// fileFormat is the on-disk layout of the history file.
+type fileFormat struct {
+	Version int               `json:"version"`
+	Records map[string]record `json:"records"`
+}
+
+// ImportResult reports what an Upsert changed.
+type ImportResult struct {
+	Added     int `json:"added"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Total     int `json:"total"` // Tasks in the history after the import
+}
+
+// Open loads the history at path, creating an empty one if the file does not exist yet.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, records: make(map[string]record)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Printf("Task history %s not found, starting empty", path)
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading task history: %w", err)
+	}
+
+	var file fileFormat
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("decoding task history %s: %w", path, err)
+	}
+	if file.Records != nil {
+		s.records = file.Records
+	}
+	log.Printf("Loaded %d tasks from history %s", len(s.records), path)
+	return s, nil
+}
+
+// Key identifies a task across imports. The platform reuses an item ID for the
+// separate pay lines of one item (e.g. Task and Exceeded Time), so the type is
+// part of the key. Tasks without an ID are keyed by a hash of their content.
+func Key(task types.Task) string {
+	if task.ID != "" {
+		return task.ID + "|" + task.Type
+	}
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%s|%s|%s|%d|%s", task.Date, task.Category, task.Duration, task.Type, task.Value, task.Status)))
+	return "noid:" + hex.EncodeToString(sum[:8])
+}
+
+// Upsert merges tasks into the history, replacing stored tasks with the same
+// Key, and saves the file.
+func (s *Store) Upsert(tasks []types.Task) (ImportResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Work on a copy so that the history in memory is unchanged if it cannot be saved
+	var result ImportResult
+	records := maps.Clone(s.records)
+	now := time.Now().UTC()
+	for _, task := range tasks {
+		key := Key(task)
+		existing, ok := records[key]
+		switch {
+		case !ok:
+			records[key] = record{Task: task, FirstSeen: now, LastSeen: now}
+			result.Added++
+		case sameTask(existing.Task, task):
+			existing.LastSeen = now
+			records[key] = existing
+			result.Unchanged++
+		default:
+			existing.Task = task
+			existing.LastSeen = now
+			records[key] = existing
+			result.Updated++
+		}
+	}
+	result.Total = len(records)
+
+	if err := s.save(records); err != nil {
+		return result, err
+	}
+	s.records = records
+	log.Printf("History import: %d added, %d updated, %d unchanged, %d total", result.Added, result.Updated, result.Unchanged, result.Total)
+	return result, nil
+}
+
+// sameTask compares two tasks field by field, using time.Time.Equal for the
+// work date since a reloaded date may carry a different *time.Location.
+func sameTask(a, b types.Task) bool {
+	if !a.WorkDate.Equal(b.WorkDate) {
+		return false
+	}
+	a.WorkDate, b.WorkDate = time.Time{}, time.Time{}
+	return a == b
+}
+
+// Tasks returns every task in the history, ordered by key so results are stable.
+func (s *Store) Tasks() []types.Task {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.records))
+	for key := range s.records {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tasks := make([]types.Task, 0, len(keys))
+	for _, key := range keys {
+		tasks = append(tasks, s.records[key].Task)
+	}
+	return tasks
+}
+
+// Len returns the number of tasks in the history.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.records)
+}
+
+// save writes records to a temporary file and renames it over the old history,
+// so a crash mid-write never leaves a truncated history. Callers hold s.mu.
+func (s *Store) save(records map[string]record) error {
+	data, err := json.Marshal(fileFormat{Version: 1, Records: records})
+	if err != nil {
+		return fmt.Errorf("encoding task history: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating task history directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("writing task history: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing task history: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing task history: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("replacing task history: %w", err)
+	}
+	return nil
+}
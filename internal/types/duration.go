@@ -0,0 +1,101 @@
+package types
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+)
+
+// Durations are carried as time.Duration rounded to the second, the precision
+// of the platform's own reports, so that summed time matches its totals. JSON
+// keeps the hour and minute numbers of the documented model.
+
+// DurationFromMinutes converts a number of minutes to a duration, rounded to
+// the nearest second.
+func DurationFromMinutes(mins float64) time.Duration {
+	return time.Duration(math.Round(mins*60)) * time.Second
+}
+
+// AverageDuration divides total by n, rounded to the nearest second. It
+// returns 0 if n is 0.
+func AverageDuration(total time.Duration, n int) time.Duration {
+	if n == 0 {
+		return 0
+	}
+	return (total / time.Duration(n)).Round(time.Second)
+}
+
+// MarshalJSON encodes the task with its duration as "durationMins".
+func (t Task) MarshalJSON() ([]byte, error) {
+	type plain Task
+	return json.Marshal(struct {
+		plain
+		DurationMins float64 `json:"durationMins"`
+	}{plain(t), t.Elapsed.Minutes()})
+}
+
+// UnmarshalJSON decodes a task written by MarshalJSON, such as one in the
+// task history or a saved result.
+func (t *Task) UnmarshalJSON(data []byte) error {
+	type plain Task
+	decoded := struct {
+		*plain
+		DurationMins float64 `json:"durationMins"`
+	}{plain: (*plain)(t)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	t.Elapsed = DurationFromMinutes(decoded.DurationMins)
+	return nil
+}
+
+// MarshalJSON encodes the bucket with its time as "hours".
+func (b Bucket) MarshalJSON() ([]byte, error) {
+	type plain Bucket
+	return json.Marshal(struct {
+		plain
+		Hours float64 `json:"hours"`
+	}{plain(b), b.Time.Hours()})
+}
+
+// MarshalJSON encodes the summary with its times as "totalHours" and
+// "avgTimePerTaskMins".
+func (s Summary) MarshalJSON() ([]byte, error) {
+	type plain Summary
+	return json.Marshal(struct {
+		plain
+		TotalHours     float64 `json:"totalHours"`
+		AvgTimePerTask float64 `json:"avgTimePerTaskMins"`
+	}{plain(s), s.TotalTime.Hours(), s.AvgTimePerTask.Minutes()})
+}
+
+// MarshalJSON encodes the currency totals with their time as "hours".
+func (c CurrencySummary) MarshalJSON() ([]byte, error) {
+	type plain CurrencySummary
+	return json.Marshal(struct {
+		plain
+		Hours float64 `json:"hours"`
+	}{plain(c), c.Time.Hours()})
+}
+
+// MarshalJSON encodes the period totals with their time as "hours".
+func (p PeriodSummary) MarshalJSON() ([]byte, error) {
+	type plain PeriodSummary
+	return json.Marshal(struct {
+		plain
+		Hours float64 `json:"hours"`
+	}{plain(p), p.Time.Hours()})
+}
+
+// MarshalJSON encodes the project totals with their times in hours and the
+// average task time in minutes.
+func (p ProjectSummary) MarshalJSON() ([]byte, error) {
+	type plain ProjectSummary
+	return json.Marshal(struct {
+		plain
+		TotalHours        float64 `json:"totalHours"`
+		TaskHours         float64 `json:"taskHours"`
+		ExceededTimeHours float64 `json:"exceededTimeHours"`
+		AvgTimePerTask    float64 `json:"avgTimePerTaskMins"`
+	}{plain(p), p.TotalTime.Hours(), p.TaskTime.Hours(), p.ExceededTime.Hours(), p.AvgTimePerTask.Minutes()})
+}
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Money is an amount in cents. It is kept as an integer so that sums over
+// thousands of tasks reconcile exactly with payout statements.
+type Money int64
+
+// MoneyFromFloat converts a float amount to cents, rounding half away from zero.
+// Use it only for derived values such as averages and rates.
+func MoneyFromFloat(f float64) Money {
+	return Money(math.Round(f * 100))
+}
+
+// Float returns the amount in currency units, for ratios and charts.
+func (m Money) Float() float64 {
+	return float64(m) / 100
+}
+
+// String formats the amount with two decimals and no currency symbol (e.g. "-2.05").
+func (m Money) String() string {
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// MarshalJSON encodes the amount as a JSON number with two decimals.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number in currency units.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	f, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return fmt.Errorf("money: %w", err)
+	}
+	*m = MoneyFromFloat(f)
+	return nil
+}
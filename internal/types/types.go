@@ -1,31 +1,173 @@
 package types
 
+import "time"
+
 // Task represents a single task entry
 type Task struct {
-	Date         string
-	ID           string
-	Category     string
-	Duration     string
-	Rate         float64
-	Value        float64
-	Type         string // Task, Exceeded Time, Mission Reward, Operation
-	Status       string
-	DurationMins float64 // Duration converted to minutes
+	Date     string        `json:"date"`       // Raw date as it appeared in the input
+	WorkDate time.Time     `json:"parsedDate"` // Date parsed from Date, zero if it could not be read
+	ID       string        `json:"id"`
+	Category string        `json:"category"`
+	Duration string        `json:"duration"`
+	Rate     Money         `json:"rate"` // Per hour
+	Value    Money         `json:"value"`
+	Currency string        `json:"currency,omitempty"` // ISO 4217 code the amounts were written in ("BRL"), empty if none was given
+	Type     string        `json:"type"`               // Task, Exceeded Time, Mission Reward, Operation
+	Status   string        `json:"status"`             // StatusPending, StatusApproved, StatusPaid, StatusRejected, or the raw value if unknown
+	Elapsed  time.Duration `json:"-"`                  // Duration parsed to the second; "durationMins" in JSON
+}
+
+// Normalized task statuses
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusPaid     = "paid"
+	StatusRejected = "rejected"
+)
+
+// Diagnostic severities
+const (
+	SeverityWarning = "warning" // Row kept, but a field was zeroed or left as-is
+	SeverityError   = "error"   // Row or line dropped entirely
+)
+
+// Diagnostic describes a problem found while parsing a single line of input
+type Diagnostic struct {
+	Line     int      `json:"line"`            // 1-based line number in the raw input (0 if unknown)
+	Raw      string   `json:"raw"`             // Raw text of the offending line or record
+	Field    string   `json:"field,omitempty"` // Field that caused the problem (e.g. "value", "type"), empty for whole-line issues
+	Severity string   `json:"severity"`        // SeverityWarning or SeverityError
+	Message  string   `json:"message"`         // In English
+	Key      string   `json:"key,omitempty"`   // Message catalog key, so Message can be shown in another language
+	Args     []string `json:"args,omitempty"`  // Arguments to the catalog message
+}
+
+// Bucket holds the totals for one group of tasks (e.g. all "Exceeded Time" items)
+type Bucket struct {
+	Count int           `json:"count"`
+	Time  time.Duration `json:"-"` // "hours" in JSON
+	Value Money         `json:"value"`
+}
+
+// Summary holds the statistics calculated by analyzer.AnalyzeData
+type Summary struct {
+	TotalTasks        int           `json:"totalTasks"` // Count of items explicitly marked as "Task"
+	TotalTime         time.Duration `json:"-"`          // Sum of durations for all item types; "totalHours" in JSON
+	TotalValue        Money         `json:"totalValue"`
+	AverageHourlyRate Money         `json:"averageHourlyRate"` // (Task + Exceeded Time value) / total hours
+	AvgTimePerTask    time.Duration `json:"-"`                 // "Task" items only, to the second; "avgTimePerTaskMins" in JSON
+	AvgValuePerTask   Money         `json:"avgValuePerTask"`   // "Task" items only
+	// Breakdown by task type
+	Tasks        Bucket `json:"tasks"`
+	ExceededTime Bucket `json:"exceededTime"`
+	Other        Bucket `json:"other"` // Mission Reward, Operation and any other type
+	// Breakdown by payment status
+	Status StatusTotals `json:"status"`
+	// Breakdown by project (Task.Category), highest value first
+	Projects []ProjectSummary `json:"projects"`
+	// Time series by work date, oldest first
+	Daily        []PeriodSummary `json:"daily"`
+	Weekly       []PeriodSummary `json:"weekly"` // ISO weeks
+	Monthly      []PeriodSummary `json:"monthly"`
+	UndatedItems int             `json:"undatedItems"` // Items left out of the time series because their date could not be read
+	// Breakdown by currency, highest value first. When MixedCurrencies is set
+	// the totals above add amounts in different currencies together.
+	Currencies      []CurrencySummary `json:"currencies"`
+	MixedCurrencies bool              `json:"mixedCurrencies"`
+}
+
+// Conversion holds task values converted into a reporting currency with a
+// table of exchange rates
+type Conversion struct {
+	Currency     string        `json:"currency"`  // ISO 4217 code of the reporting currency
+	Value        Money         `json:"value"`     // Sum of the converted values
+	Converted    int           `json:"converted"` // Tasks included in Value
+	Unconverted  int           `json:"unconverted"`
+	MissingRates []MissingRate `json:"missingRates,omitempty"` // Each rate that was needed but not found
+}
+
+// MissingRate is an exchange rate a conversion needed but did not find
+type MissingRate struct {
+	Currency string    `json:"currency"` // ISO 4217 code, empty if the task named none and there is no default
+	Date     time.Time `json:"date"`     // Work date of the task, zero if it had none
+}
+
+// CurrencySummary holds the totals for all tasks written in one currency
+type CurrencySummary struct {
+	Currency  string        `json:"currency"` // ISO 4217 code, empty for amounts written without a currency
+	ItemCount int           `json:"itemCount"`
+	Time      time.Duration `json:"-"` // "hours" in JSON
+	Value     Money         `json:"value"`
+}
+
+// StatusTotals splits tasks by payment status so that money that may never
+// arrive is not planned around
+type StatusTotals struct {
+	Paid      Bucket `json:"paid"`
+	Approved  Bucket `json:"approved"`
+	Pending   Bucket `json:"pending"` // Also items with no or an unrecognised status
+	Rejected  Bucket `json:"rejected"`
+	Confirmed Money  `json:"confirmed"` // Paid + Approved value
+	Expected  Money  `json:"expected"`  // Confirmed + Pending value, rejected excluded
+}
+
+// PeriodSummary holds the totals for all tasks worked in one day, ISO week or month
+type PeriodSummary struct {
+	Period        string        `json:"period"` // "2025-03-30", "2025-W13" or "2025-03"
+	Start         time.Time     `json:"start"`  // First day of the period
+	ItemCount     int           `json:"itemCount"`
+	Time          time.Duration `json:"-"` // "hours" in JSON
+	Value         Money         `json:"value"`
+	EffectiveRate Money         `json:"effectiveRate"` // Value / hours
+}
+
+// ProjectSummary holds the totals for all tasks sharing a project category
+type ProjectSummary struct {
+	Name           string        `json:"name"`      // Task.Category, empty for tasks without a project
+	TaskCount      int           `json:"taskCount"` // Count of "Task" items
+	ItemCount      int           `json:"itemCount"` // Count of all items of any type
+	TotalTime      time.Duration `json:"-"`         // "totalHours" in JSON
+	TaskTime       time.Duration `json:"-"`         // "taskHours" in JSON
+	ExceededTime   time.Duration `json:"-"`         // "exceededTimeHours" in JSON
+	Value          Money         `json:"value"`
+	EffectiveRate  Money         `json:"effectiveRate"` // Value / total hours, all item types
+	AvgTimePerTask time.Duration `json:"-"`             // "Task" items only, to the second; "avgTimePerTaskMins" in JSON
 }
 
 // TemplateData holds data to be passed to HTML templates
 type TemplateData struct {
-	RawInput          string
-	HasResults        bool
-	TotalTasks        int
-	TotalHours        string // Formatted string (e.g., "X.XX horas (Yh Zmin)")
-	TotalValue        string // Formatted string (e.g., "X.XX")
+	RawInput   string
+	Profiles   []string // Names of the CSV mapping profiles offered in the upload form
+	Profile    string   // Profile the input was read with, empty for the built-in column names
+	HasResults bool
+	TotalTasks int
+	TotalHours string // Formatted string (e.g., "X.XX horas (Yh Zmin)")
+	TotalValue string // Formatted string with currency (e.g., "$X.XX" or "R$ X,XX")
+	// Total value converted with the exchange rate table, if one is configured
+	ConvertedCurrency string
+	ConvertedValue    string
+	UnconvertedCount  int    // Tasks left out of ConvertedValue for lack of a rate
+	MissingRates      string // e.g. "USD 2025-03-30, EUR undated"
 	TasksValue        string
 	ExceededTimeValue string
 	OtherValue        string
+	// Earnings by status (formatted strings)
+	ConfirmedValue    string
+	PaidValue         string
+	ApprovedValue     string
+	PendingValue      string
+	RejectedValue     string
+	ExpectedValue     string
+	PendingCount      int
+	RejectedCount     int
 	AverageHourlyRate string
+	Currency          string // Currency symbol, for the chart tooltips
 	CurrentYear       int
-	InputSource       string // "csv" or "text"
+	InputSource       string // Parser format used (parser.FormatCSV, parser.FormatText, ...)
+	// Input format detection (formatted strings)
+	DetectedFormat   string // Display name of the chosen format
+	FormatConfidence string // e.g. "95%"
+	FormatReason     string
 	// Detailed hour breakdowns (formatted strings)
 	TaskHours         string
 	ExceededTimeHours string
@@ -35,9 +177,45 @@ type TemplateData struct {
 	AvgValuePerTask string // Formatted string (e.g., "$X.XX")
 	// For visualization (progress bars)
 	RawHourPercentages []float64 // Task%, ExceededTime%, Other%
+	RawValues          []float64 // Task, ExceededTime and Other value
+	// Per-project breakdown section
+	Projects []ProjectDisplay
+	// Time series section: one group each for day, week and month
+	PeriodGroups []PeriodGroupDisplay
+	UndatedItems int
+	// Per-currency totals, shown when the tasks are in more than one currency
+	MixedCurrencies bool
+	Currencies      []CurrencyDisplay
 	// Task details section
 	ShowDetails bool
 	Tasks       []TaskDisplay // Tasks formatted for display
+	// Parse warnings section
+	Diagnostics []Diagnostic // With Message in the page language
+	// Task history
+	HistoryEnabled bool
+	HistoryTotal   int    // Tasks stored in the history
+	HistoryImport  string // Outcome of saving this upload, if it was saved
+	HistoryView    bool   // Results are over the stored history rather than an upload
+	HistoryFrom    string // Date range of a history view, as entered
+	HistoryTo      string
+	// Saved result permalinks
+	SharingEnabled bool
+	ResultID       string // Set when rendering a saved result at /r/{id}
+	PermalinkURL   string
+	// Interface language
+	Lang      string            // Selected locale tag, e.g. "pt-BR"
+	T         map[string]string // Message catalog for Lang, used as {{ .T.key }}
+	Languages []LanguageOption  // Language switcher entries
+	// Switch language by re-posting the input, as results from a POST cannot be fetched again
+	RepostLanguage bool
+}
+
+// LanguageOption is one entry of the language switcher
+type LanguageOption struct {
+	Tag    string
+	Name   string
+	URL    string // The current page in this language
+	Active bool
 }
 
 // TaskDisplay represents a task formatted for display in the HTML table
@@ -52,3 +230,46 @@ type TaskDisplay struct {
 	Status       string
 	DurationMins string // Formatted string (e.g., "X.XX mins" or "-")
 }
+
+// ProjectDisplay represents a project row formatted for the per-project table.
+// The Sort* fields hold raw values for client-side sorting.
+type ProjectDisplay struct {
+	Name              string
+	TaskCount         int
+	TotalHours        string
+	TaskHours         string
+	ExceededTimeHours string
+	Value             string
+	EffectiveRate     string
+	AvgTimePerTask    string
+	SortTotalHours    float64
+	SortTaskHours     float64
+	SortExceededHours float64
+	SortValue         float64
+	SortEffectiveRate float64
+	SortAvgTime       float64
+}
+
+// PeriodGroupDisplay is one of the daily/weekly/monthly tables on the results page
+type PeriodGroupDisplay struct {
+	Key   string // "day", "week" or "month"
+	Label string
+	Rows  []PeriodDisplay
+}
+
+// CurrencyDisplay represents a per-currency totals row formatted for display
+type CurrencyDisplay struct {
+	Currency  string
+	ItemCount int
+	Hours     string
+	Value     string
+}
+
+// PeriodDisplay represents a time-series row formatted for display
+type PeriodDisplay struct {
+	Period        string
+	ItemCount     int
+	Hours         string
+	Value         string
+	EffectiveRate string
+}
@@ -89,6 +89,47 @@ document.addEventListener('DOMContentLoaded', function() {
         });
     }
     
+    // Time series tabs: show the day, week or month table
+    const periodTabs = document.querySelectorAll('.period-tab');
+    periodTabs.forEach(function(tab) {
+        tab.addEventListener('click', function() {
+            const period = tab.getAttribute('data-period');
+            periodTabs.forEach(function(t) {
+                t.classList.toggle('active', t === tab);
+            });
+            document.querySelectorAll('.period-panel').forEach(function(panel) {
+                panel.style.display = panel.getAttribute('data-period') === period ? 'block' : 'none';
+            });
+        });
+    });
+
+    // Sortable tables: click a header to sort by that column, click again to reverse
+    document.querySelectorAll('.sortable-table').forEach(function(table) {
+        table.querySelectorAll('th[data-sort]').forEach(function(header, columnIndex) {
+            header.addEventListener('click', function() {
+                const numeric = header.getAttribute('data-sort') === 'number';
+                const ascending = header.getAttribute('data-sort-dir') !== 'asc';
+                const tbody = table.querySelector('tbody');
+                const rows = Array.from(tbody.querySelectorAll('tr'));
+
+                rows.sort(function(a, b) {
+                    const aValue = a.children[columnIndex].getAttribute('data-sort-value');
+                    const bValue = b.children[columnIndex].getAttribute('data-sort-value');
+                    const cmp = numeric
+                        ? (parseFloat(aValue) || 0) - (parseFloat(bValue) || 0)
+                        : aValue.localeCompare(bValue);
+                    return ascending ? cmp : -cmp;
+                });
+                rows.forEach(function(row) { tbody.appendChild(row); });
+
+                table.querySelectorAll('th[data-sort]').forEach(function(th) {
+                    th.removeAttribute('data-sort-dir');
+                });
+                header.setAttribute('data-sort-dir', ascending ? 'asc' : 'desc');
+            });
+        });
+    });
+
     // Modal functionality for How to Use button
     const modal = document.getElementById('howToUseModal');
     const howToUseBtn = document.getElementById('howToUseButton');
@@ -120,16 +161,26 @@ document.addEventListener('DOMContentLoaded', function() {
         const otherHoursPercent = parseFloat(dataContainer.getAttribute('data-other-percent')) || 0;
         
         // Get values for the values chart
-        const taskValue = parseFloat(dataContainer.getAttribute('data-task-value').replace(/[^0-9.]/g, '')) || 0;
-        const exceededValue = parseFloat(dataContainer.getAttribute('data-exceeded-value').replace(/[^0-9.]/g, '')) || 0;
-        const otherValue = parseFloat(dataContainer.getAttribute('data-other-value').replace(/[^0-9.]/g, '')) || 0;
+        const taskValue = parseFloat(dataContainer.getAttribute('data-task-value')) || 0;
+        const exceededValue = parseFloat(dataContainer.getAttribute('data-exceeded-value')) || 0;
+        const otherValue = parseFloat(dataContainer.getAttribute('data-other-value')) || 0;
+        const currency = dataContainer.getAttribute('data-currency') || '';
+
+        // Labels and number format follow the page language
+        const locale = dataContainer.getAttribute('data-locale') || undefined;
+        const formatNumber = value => value.toLocaleString(locale, { minimumFractionDigits: 2, maximumFractionDigits: 2 });
+        const typeLabels = [
+            dataContainer.getAttribute('data-label-tasks'),
+            dataContainer.getAttribute('data-label-exceeded'),
+            dataContainer.getAttribute('data-label-other')
+        ];
         
         // Create hours chart
         const hoursChartCtx = document.getElementById('hoursChart').getContext('2d');
         const hoursChart = new Chart(hoursChartCtx, {
             type: 'pie',
             data: {
-                labels: ['Tarefas', 'Tempo Excedido', 'Outros'],
+                labels: typeLabels,
                 datasets: [{
                     data: [taskHoursPercent, exceededHoursPercent, otherHoursPercent],
                     backgroundColor: [
@@ -154,7 +205,7 @@ document.addEventListener('DOMContentLoaded', function() {
                     },
                     title: {
                         display: true,
-                        text: 'Distribuição de Horas por Tipo',
+                        text: dataContainer.getAttribute('data-title-hours'),
                         font: {
                             size: 16
                         }
@@ -162,7 +213,7 @@ document.addEventListener('DOMContentLoaded', function() {
                     tooltip: {
                         callbacks: {
                             label: function(context) {
-                                return context.label + ': ' + context.raw.toFixed(2) + '%';
+                                return context.label + ': ' + formatNumber(context.raw) + '%';
                             }
                         }
                     }
@@ -175,7 +226,7 @@ document.addEventListener('DOMContentLoaded', function() {
         const valuesChart = new Chart(valuesChartCtx, {
             type: 'pie',
             data: {
-                labels: ['Tarefas', 'Tempo Excedido', 'Outros'],
+                labels: typeLabels,
                 datasets: [{
                     data: [taskValue, exceededValue, otherValue],
                     backgroundColor: [
@@ -200,7 +251,7 @@ document.addEventListener('DOMContentLoaded', function() {
                     },
                     title: {
                         display: true,
-                        text: 'Distribuição de Valores',
+                        text: dataContainer.getAttribute('data-title-values'),
                         font: {
                             size: 16
                         }
@@ -208,7 +259,7 @@ document.addEventListener('DOMContentLoaded', function() {
                     tooltip: {
                         callbacks: {
                             label: function(context) {
-                                return context.label + ': $' + context.raw.toFixed(2);
+                                return context.label + ': ' + currency + formatNumber(context.raw);
                             }
                         }
                     }